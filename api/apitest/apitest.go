@@ -0,0 +1,215 @@
+// Package apitest sends requests through the whole application, built by api.New like main does,
+// with every handler on an in-memory store. Responses are checked against the JSON:API envelope:
+// success has data and no errors, failure has errors and no data.
+//
+// Usage:
+//
+//	app := apitest.New(t)
+//	kelas := schema.KelasResponse{}
+//	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, &kelas)
+//	app.Request(http.MethodGet, "/kelass/9", "").Error(http.StatusNotFound, apierror.KelasNotFound)
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/api"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+	"github.com/syukur91/ischool-monitor/service/memory"
+)
+
+// Tenant is tenant of every request path
+const Tenant = "test"
+
+// App is application of one test. Its store starts empty.
+type App struct {
+	t     *testing.T
+	Echo  *echo.Echo
+	Store *memory.Store
+}
+
+// Response is recorded response of one request
+type Response struct {
+	t      *testing.T
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+// envelope is JSON:API document. Members are kept raw, so missing member can be told from empty one.
+type envelope struct {
+	Data   json.RawMessage  `json:"data"`
+	Errors []response.Error `json:"errors"`
+	Links  json.RawMessage  `json:"links"`
+	Meta   json.RawMessage  `json:"meta"`
+	Total  *int             `json:"total"`
+}
+
+// New returns application with every handler on a new in-memory store
+func New(t *testing.T) *App {
+	t.Helper()
+	return NewWithConfig(t, api.Config{})
+}
+
+// NewWithConfig returns application of config with every handler on a new in-memory store.
+// Logger, version and registerer are set by it.
+func NewWithConfig(t *testing.T, config api.Config) *App {
+	t.Helper()
+
+	config.Logger = zap.NewNop()
+	config.Version = "test"
+	config.Registerer = prometheus.NewRegistry()
+
+	store := memory.New()
+	e := api.New(config, api.NewServices(store))
+
+	return &App{t: t, Echo: e, Store: store}
+}
+
+// Request sends JSON body to path under tenant. Empty body sends no body.
+func (a *App) Request(method string, path string, body string) *Response {
+	a.t.Helper()
+	return a.RequestWithContentType(method, path, echo.MIMEApplicationJSON, body)
+}
+
+// RequestWithContentType sends body of content type to path under tenant
+func (a *App) RequestWithContentType(method string, path string, contentType string, body string) *Response {
+	a.t.Helper()
+	return a.RequestWithHeader(method, path, http.Header{echo.HeaderContentType: {contentType}}, body)
+}
+
+// RequestWithHeader sends JSON body with header to path under tenant, e.g. If-Match.
+// Content type of header replaces JSON.
+func (a *App) RequestWithHeader(method string, path string, header http.Header, body string) *Response {
+	a.t.Helper()
+
+	req := httptest.NewRequest(method, "/"+Tenant+path, strings.NewReader(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != "" && req.Header.Get(echo.HeaderContentType) == "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if body == "" {
+		req.Header.Del(echo.HeaderContentType)
+	}
+	rec := httptest.NewRecorder()
+	a.Echo.ServeHTTP(rec, req)
+
+	return &Response{t: a.t, Code: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+}
+
+// Data checks status and success envelope, and decodes its data into v. v may be nil.
+func (r *Response) Data(status int, v interface{}) {
+	r.t.Helper()
+
+	doc := r.envelope(status)
+	if len(doc.Errors) > 0 {
+		r.t.Fatalf("expect no errors, but got %s", r.Body)
+	}
+	if len(doc.Data) == 0 || string(doc.Data) == "null" {
+		r.t.Fatalf("expect data, but got %s", r.Body)
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(doc.Data, v); err != nil {
+			r.t.Fatalf("decode data failed: %s: %s", err, doc.Data)
+		}
+	}
+}
+
+// Grid checks status and grid envelope, decodes its rows into v and returns its total.
+// Grid envelope always has data, an empty array when no row matches.
+func (r *Response) Grid(status int, v interface{}) int {
+	r.t.Helper()
+
+	doc := r.envelope(status)
+	if len(doc.Errors) > 0 {
+		r.t.Fatalf("expect no errors, but got %s", r.Body)
+	}
+	if !bytes.HasPrefix(doc.Data, []byte("[")) {
+		r.t.Fatalf("expect data array, but got %s", r.Body)
+	}
+	if doc.Total == nil {
+		r.t.Fatalf("expect total, but got %s", r.Body)
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(doc.Data, v); err != nil {
+			r.t.Fatalf("decode data failed: %s: %s", err, doc.Data)
+		}
+	}
+
+	return *doc.Total
+}
+
+// Error checks status and error envelope with one error of code. Error repeats the status,
+// is titled with its text and explains itself in detail.
+func (r *Response) Error(status int, code apierror.Code) {
+	r.t.Helper()
+
+	doc := r.envelope(status)
+	if len(doc.Data) > 0 {
+		r.t.Fatalf("expect no data, but got %s", r.Body)
+	}
+	if len(doc.Errors) != 1 {
+		r.t.Fatalf("expect 1 error, but got %s", r.Body)
+	}
+
+	e := doc.Errors[0]
+	if e.Code != string(code) {
+		r.t.Fatalf("expect error code %s, but got %s", code, e.Code)
+	}
+	if e.Status != status {
+		r.t.Fatalf("expect error status %d, but got %d", status, e.Status)
+	}
+	if e.Title != apierror.StatusText(status) {
+		r.t.Fatalf("expect error title %s, but got %s", apierror.StatusText(status), e.Title)
+	}
+	if e.Detail == "" {
+		r.t.Fatalf("expect error detail, but got %s", r.Body)
+	}
+}
+
+// Empty checks status and that body is empty
+func (r *Response) Empty(status int) {
+	r.t.Helper()
+
+	if r.Code != status {
+		r.t.Fatalf("expect status %d, but got %d: %s", status, r.Code, r.Body)
+	}
+	if len(r.Body) > 0 {
+		r.t.Fatalf("expect empty body, but got %s", r.Body)
+	}
+}
+
+// envelope checks status and JSON content type, and decodes body. Unknown members fail.
+func (r *Response) envelope(status int) *envelope {
+	r.t.Helper()
+
+	if r.Code != status {
+		r.t.Fatalf("expect status %d, but got %d: %s", status, r.Code, r.Body)
+	}
+	if contentType := r.Header.Get(echo.HeaderContentType); !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		r.t.Fatalf("expect content type %s, but got %s", echo.MIMEApplicationJSON, contentType)
+	}
+
+	doc := &envelope{}
+	decoder := json.NewDecoder(bytes.NewReader(r.Body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(doc); err != nil {
+		r.t.Fatalf("expect JSON:API document, but got %s: %s", err, r.Body)
+	}
+
+	return doc
+}
@@ -0,0 +1,330 @@
+// Package api builds the HTTP application: echo with its middleware, error handler, validator and
+// routes of every handler. main serves it with services backed by database, tests send requests
+// to it through httptest, see package apitest.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/labstack/echo/middleware"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/syukur91/ischool-monitor/api/controller"
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	Middleware "github.com/syukur91/ischool-monitor/pkg/middleware"
+	"github.com/syukur91/ischool-monitor/pkg/openapi"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+	"github.com/syukur91/ischool-monitor/pkg/period"
+	"github.com/syukur91/ischool-monitor/pkg/precondition"
+	"github.com/syukur91/ischool-monitor/pkg/query"
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+type (
+	// CustomValidator is
+	CustomValidator struct {
+		validator *validator.Validate
+	}
+
+	// Binder binds JSON Merge Patch body as JSON, and other bodies as echo does
+	Binder struct {
+		echo.DefaultBinder
+	}
+
+	// Config of application
+	Config struct {
+		Logger  *zap.Logger
+		Version string
+
+		// LogBody and LogHeader log request and response body and headers, with PII redacted
+		LogBody   bool
+		LogHeader bool
+
+		// QueryTimeout is how long request may take, with its queries. GridQueryTimeout is
+		// the same for grid routes, which filter and count whole tables.
+		// Optional. Default middleware.DefaultTimeout.
+		QueryTimeout     time.Duration
+		GridQueryTimeout time.Duration
+
+		// RequireIfMatch makes update and delete without If-Match fail with 428, so clients can't
+		// overwrite changes they haven't seen. Without it If-Match is only checked when sent.
+		RequireIfMatch bool
+
+		// Registerer registers request metrics.
+		// Optional. Default prometheus.DefaultRegisterer.
+		Registerer prometheus.Registerer
+
+		// Tenants label request metrics by their name, other tenants are labelled "other".
+		// Optional. Default the first 100 tenants that make a request.
+		Tenants []string
+	}
+
+	// Services are repositories of handlers. Routes of nil repository are not registered
+	Services struct {
+		MataPelajaran  service.Mata_PelajaranRepository
+		Kelas          service.KelasRepository
+		WaliKelas      service.Wali_KelasRepository
+		KelasWaliKelas service.Kelas_Wali_KelasRepository
+		Siswa          service.SiswaRepository
+		User           service.UserRepository
+		Semester       service.SemesterRepository
+		KenaikanKelas  service.KenaikanKelasRepository
+		RiwayatKelas   service.RiwayatKelasRepository
+		Kehadiran      service.KehadiranRepository
+	}
+)
+
+// Validate is
+func (cv *CustomValidator) Validate(i interface{}) error {
+	return cv.validator.Struct(i)
+}
+
+// Bind ...
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), optional.MIMEApplicationMergePatchJSON) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	err := json.NewDecoder(c.Request().Body).Decode(i)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	return nil
+}
+
+// New returns echo with middleware, error handler, validator and routes under /:tenant
+func New(config Config, services Services) *echo.Echo {
+	// @
+	// Initialize echo
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.Validator = &CustomValidator{validator: validator.New()}
+	e.Binder = &Binder{}
+	e.HTTPErrorHandler = Middleware.ErrorHandler(config.Logger)
+
+	loggerConfig := Middleware.LoggerConfig{
+		Skipper: func(c echo.Context) bool {
+			if strings.Contains(c.Request().RequestURI, "/public/") ||
+				strings.Contains(c.Request().RequestURI, "favicon") ||
+				strings.Contains(c.Request().RequestURI, "/js/") ||
+				c.Path() == "/metrics" {
+				return true
+			}
+			return false
+		},
+		AppName:   "ischool-monitor",
+		Logger:    config.Logger,
+		LogBody:   config.LogBody,
+		LogHeader: config.LogHeader,
+		BodySkipper: func(c echo.Context) bool {
+			// grid responses are large and only repeat stored data
+			return strings.HasSuffix(c.Path(), "-grid")
+		},
+	}
+
+	metricsConfig := Middleware.MetricsConfig{
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/metrics"
+		},
+		Registerer: config.Registerer,
+		Tenants:    config.Tenants,
+	}
+
+	e.Use(Middleware.RequestIDWithConfig(Middleware.RequestIDConfig{Logger: config.Logger}))
+	e.Use(Middleware.TracingWithConfig(Middleware.TracingConfig{Skipper: metricsConfig.Skipper, Logger: config.Logger}))
+	e.Use(Middleware.MetricsWithConfig(metricsConfig))
+	e.Use(Middleware.LoggerWithConfig(loggerConfig))
+	e.Use(middleware.Recover())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		// clients read ETag to send it back in If-Match
+		ExposeHeaders: []string{precondition.HeaderETag},
+	}))
+	e.Use(Middleware.PreconditionWithConfig(Middleware.PreconditionConfig{RequireIfMatch: config.RequireIfMatch}))
+	if services.Semester != nil {
+		// grids of kelas and siswa, and rekap kehadiran, are of active semester
+		e.Use(Middleware.PeriodWithConfig(Middleware.PeriodConfig{Resolver: semesterPeriod(services.Semester)}))
+	}
+	e.Use(Middleware.TimeoutWithConfig(Middleware.TimeoutConfig{
+		Skipper: metricsConfig.Skipper,
+		Timeout: func(c echo.Context) time.Duration {
+			if strings.HasSuffix(c.Path(), "-grid") && config.GridQueryTimeout > 0 {
+				return config.GridQueryTimeout
+			}
+			if config.QueryTimeout > 0 {
+				return config.QueryTimeout
+			}
+			return Middleware.DefaultTimeout
+		},
+	}))
+
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
+	// @
+	// Routes
+	r := e.Group("/:tenant")
+
+	// Mandatory hello world
+	r.GET("/hello", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Hello "+c.Param("tenant")+"! This is API version: "+config.Version)
+	})
+
+	// @
+	// Handlers
+	if services.MataPelajaran != nil {
+		mataPelajaranHandler := &controller.Mata_PelajaranHandler{
+			Mata_PelajaranService: services.MataPelajaran,
+		}
+		mataPelajaranHandler.SetRoutes(r)
+	}
+	if services.Kelas != nil {
+		kelasHandler := &controller.KelasHandler{
+			KelasService: services.Kelas,
+		}
+		kelasHandler.SetRoutes(r)
+	}
+	if services.WaliKelas != nil {
+		waliKelasHandler := &controller.Wali_KelasHandler{
+			Wali_KelasService: services.WaliKelas,
+		}
+		waliKelasHandler.SetRoutes(r)
+	}
+	if services.KelasWaliKelas != nil {
+		kelasWaliKelasHandler := &controller.Kelas_Wali_KelasHandler{
+			Kelas_Wali_KelasService: services.KelasWaliKelas,
+		}
+		kelasWaliKelasHandler.SetRoutes(r)
+	}
+	if services.Siswa != nil {
+		siswaHandler := &controller.SiswaHandler{
+			SiswaService: services.Siswa,
+		}
+		siswaHandler.SetRoutes(r)
+	}
+	if services.User != nil {
+		userHandler := &controller.UserHandler{
+			UserService: services.User,
+		}
+		userHandler.SetRoutes(r)
+	}
+	if services.Semester != nil {
+		semesterHandler := &controller.SemesterHandler{
+			SemesterService: services.Semester,
+		}
+		semesterHandler.SetRoutes(r)
+	}
+	if services.KenaikanKelas != nil {
+		kenaikanKelasHandler := &controller.KenaikanKelasHandler{
+			KenaikanKelasService: services.KenaikanKelas,
+		}
+		kenaikanKelasHandler.SetRoutes(r)
+	}
+	if services.RiwayatKelas != nil {
+		riwayatKelasHandler := &controller.RiwayatKelasHandler{
+			RiwayatKelasService: services.RiwayatKelas,
+		}
+		riwayatKelasHandler.SetRoutes(r)
+	}
+	if services.Kehadiran != nil {
+		kehadiranHandler := &controller.KehadiranHandler{
+			KehadiranService: services.Kehadiran,
+		}
+		kehadiranHandler.SetRoutes(r)
+	}
+
+	// @
+	// API documentation, built from routes above
+	r.GET("/openapi.json", openapi.Handler(e, "/:tenant", openapi.Info{
+		Title:   "ischool-monitor",
+		Version: config.Version,
+	}))
+	r.GET("/docs", openapi.UIHandler)
+	r.GET("/docs/*", openapi.UIHandler)
+
+	return e
+}
+
+// NewServices returns repository of every handler from store, on database in main or in memory in
+// tests. Handlers of services that store doesn't have, like kenaikan kelas in memory, are not
+// registered.
+func NewServices(store service.Store) Services {
+	services := Services{
+		MataPelajaran:  store.Mata_Pelajaran(),
+		Kelas:          store.Kelas(),
+		WaliKelas:      store.Wali_Kelas(),
+		KelasWaliKelas: store.Kelas_Wali_Kelas(),
+		Siswa:          store.Siswa(),
+		User:           store.User(),
+		Semester:       store.Semester(),
+	}
+
+	if s, ok := store.(interface {
+		KenaikanKelas() service.KenaikanKelasRepository
+	}); ok {
+		services.KenaikanKelas = s.KenaikanKelas()
+	}
+	if s, ok := store.(interface {
+		RiwayatKelas() service.RiwayatKelasRepository
+	}); ok {
+		services.RiwayatKelas = s.RiwayatKelas()
+	}
+	if s, ok := store.(interface {
+		Kehadiran() service.KehadiranRepository
+	}); ok {
+		services.Kehadiran = s.Kehadiran()
+	}
+
+	return services
+}
+
+// semesterPeriod returns resolver of period of semester with id, or of active semester, from
+// semester repository
+func semesterPeriod(semesters service.SemesterRepository) period.Resolver {
+	return func(ctx context.Context, id string) (*period.Period, error) {
+		if id != "" {
+			if _, err := strconv.Atoi(id); err != nil {
+				return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.RequestInvalid, "Semester parameter "+id+" is not semester id or "+period.All, errors.Wrap(err, "semesterperiod: invalid semester parameter"))
+			}
+
+			semester, err := semesters.GetSemester(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return periodOf(semester), nil
+		}
+
+		active, _, err := semesters.ListSemesters(ctx, &query.GridParams{
+			PageSize:  1,
+			HasFilter: true,
+			Filter: query.GridFilterMain{
+				Logic:   "and",
+				Filters: []query.GridFilter{{Field: "aktif", Operator: "eq", Value: "true"}},
+			},
+		})
+		if err != nil || len(active) == 0 {
+			return nil, err
+		}
+		return periodOf(&active[0]), nil
+	}
+}
+
+func periodOf(semester *schema.SemesterResponse) *period.Period {
+	return &period.Period{
+		SemesterID:  semester.ID,
+		TahunAjaran: semester.TahunAjaran,
+		Mulai:       semester.Mulai,
+		Akhir:       semester.Akhir,
+	}
+}
@@ -0,0 +1,534 @@
+package api_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/api"
+	"github.com/syukur91/ischool-monitor/api/apitest"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+	"github.com/syukur91/ischool-monitor/pkg/precondition"
+	"github.com/syukur91/ischool-monitor/service"
+	"github.com/syukur91/ischool-monitor/service/memory"
+)
+
+const (
+	malformedBody = `{"nama":`
+	missingPath   = "/2147483647"
+)
+
+// TestHandlers runs create, grid, get, update, replace and delete of every handler against one app.
+// Rows referenced by foreign keys are created by prepare.
+func TestHandlers(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName     string
+		path             string
+		prepare          func(app *apitest.App)
+		createBody       string
+		invalidBody      string
+		nama             string
+		updateBody       string
+		updatedNama      string
+		expectedNotFound apierror.Code
+	}{
+		{
+			scenarioName:     "mata pelajaran",
+			path:             "/mata_pelajarans",
+			createBody:       `{"nama":"Matematika","kode":"MTK-1","tingkat":1}`,
+			invalidBody:      `{"nama":"Matematika","tingkat":1}`,
+			nama:             "Matematika",
+			updateBody:       `{"nama":"Matematika Dasar"}`,
+			updatedNama:      "Matematika Dasar",
+			expectedNotFound: apierror.MatpelNotFound,
+		},
+		{
+			scenarioName:     "kelas",
+			path:             "/kelass",
+			createBody:       `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`,
+			invalidBody:      `{"nama":"1A","tahun_ajaran":2026}`,
+			nama:             "1A",
+			updateBody:       `{"nama":"1B"}`,
+			updatedNama:      "1B",
+			expectedNotFound: apierror.KelasNotFound,
+		},
+		{
+			scenarioName:     "wali kelas",
+			path:             "/wali_kelass",
+			createBody:       `{"nama":"Ibu Siti Rahman","alamat":"Jl. Merdeka No. 1, Bandung","telpon":"0812-0000-0001"}`,
+			invalidBody:      `{"nama":"Ibu Siti Rahman","alamat":"Jl. Merdeka No. 1, Bandung"}`,
+			nama:             "Ibu Siti Rahman",
+			updateBody:       `{"nama":"Ibu Siti Rahmawati"}`,
+			updatedNama:      "Ibu Siti Rahmawati",
+			expectedNotFound: apierror.WaliKelasNotFound,
+		},
+		{
+			scenarioName: "siswa",
+			path:         "/siswas",
+			prepare: func(app *apitest.App) {
+				app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+			},
+			createBody:       `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1,"alamat":"Jl. Sudirman No. 2, Bandung"}`,
+			invalidBody:      `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1}`,
+			nama:             "Budi Santoso",
+			updateBody:       `{"nama":"Budi Santosa"}`,
+			updatedNama:      "Budi Santosa",
+			expectedNotFound: apierror.SiswaNotFound,
+		},
+		{
+			scenarioName:     "user",
+			path:             "/users",
+			createBody:       `{"nama":"Admin","alamat":"Jl. Asia Afrika No. 3, Bandung","password":"rahasia","telepon":"0812-0000-0002"}`,
+			invalidBody:      `{"nama":"Admin","alamat":"Jl. Asia Afrika No. 3, Bandung","telepon":"0812-0000-0002"}`,
+			nama:             "Admin",
+			updateBody:       `{"nama":"Administrator"}`,
+			updatedNama:      "Administrator",
+			expectedNotFound: apierror.UserNotFound,
+		},
+	}
+
+	type row struct {
+		ID   int    `json:"id"`
+		Nama string `json:"nama"`
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			app := apitest.New(t)
+			if v.prepare != nil {
+				v.prepare(app)
+			}
+
+			// create
+			created := row{}
+			app.Request(http.MethodPost, v.path, v.createBody).Data(http.StatusOK, &created)
+			if created.ID != 1 || created.Nama != v.nama {
+				t.Errorf("expect created row 1 %s, but got %d %s", v.nama, created.ID, created.Nama)
+				return
+			}
+			app.Request(http.MethodPost, v.path, v.invalidBody).Error(http.StatusUnprocessableEntity, apierror.RequestInvalid)
+			app.Request(http.MethodPost, v.path, malformedBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.RequestWithContentType(http.MethodPost, v.path, echo.MIMETextPlain, v.createBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+
+			// grid
+			rows := []row{}
+			total := app.Request(http.MethodPost, v.path+"-grid", `{"skip":0,"pageSize":10,"filter":{"logic":"and","filters":[{"field":"nama","operator":"eq","value":"`+v.nama+`"}]}}`).Grid(http.StatusOK, &rows)
+			if total != 1 || len(rows) != 1 || rows[0].ID != created.ID {
+				t.Errorf("expect grid of row %d, but got total %d rows %v", created.ID, total, rows)
+				return
+			}
+			total = app.Request(http.MethodPost, v.path+"-grid", `{"skip":0,"pageSize":10,"filter":{"logic":"and","filters":[{"field":"nama","operator":"eq","value":"-"}]}}`).Grid(http.StatusOK, &rows)
+			if total != 0 || len(rows) != 0 {
+				t.Errorf("expect empty grid, but got total %d rows %v", total, rows)
+				return
+			}
+			app.Request(http.MethodPost, v.path+"-grid", malformedBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.RequestWithContentType(http.MethodPost, v.path+"-grid", echo.MIMETextPlain, `{"skip":0,"pageSize":10}`).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+
+			// get
+			got := row{}
+			app.Request(http.MethodGet, v.path+"/1", "").Data(http.StatusOK, &got)
+			if got != created {
+				t.Errorf("expect row %v, but got %v", created, got)
+				return
+			}
+			app.Request(http.MethodGet, v.path+missingPath, "").Error(http.StatusNotFound, v.expectedNotFound)
+
+			// update
+			updated := row{}
+			app.RequestWithContentType(http.MethodPatch, v.path+"/1", optional.MIMEApplicationMergePatchJSON, v.updateBody).Data(http.StatusOK, &updated)
+			if updated.ID != created.ID || updated.Nama != v.updatedNama {
+				t.Errorf("expect updated row %d %s, but got %d %s", created.ID, v.updatedNama, updated.ID, updated.Nama)
+				return
+			}
+			app.Request(http.MethodPatch, v.path+"/1", malformedBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.RequestWithContentType(http.MethodPatch, v.path+"/1", optional.MIMEApplicationMergePatchJSON, malformedBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.RequestWithContentType(http.MethodPatch, v.path+"/1", echo.MIMETextPlain, v.updateBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.Request(http.MethodPatch, v.path+missingPath, v.updateBody).Error(http.StatusNotFound, v.expectedNotFound)
+
+			// replace
+			replaced := row{}
+			app.Request(http.MethodPut, v.path+"/1", v.createBody).Data(http.StatusOK, &replaced)
+			if replaced != created {
+				t.Errorf("expect replaced row %v, but got %v", created, replaced)
+				return
+			}
+			app.Request(http.MethodPut, v.path+"/1", v.invalidBody).Error(http.StatusUnprocessableEntity, apierror.RequestInvalid)
+			app.Request(http.MethodPut, v.path+"/1", malformedBody).Error(http.StatusUnprocessableEntity, apierror.RequestBindFailed)
+			app.Request(http.MethodPut, v.path+missingPath, v.createBody).Error(http.StatusNotFound, v.expectedNotFound)
+
+			// delete
+			app.Request(http.MethodDelete, v.path+"/1", "").Empty(http.StatusOK)
+			app.Request(http.MethodDelete, v.path+"/1", "").Error(http.StatusNotFound, v.expectedNotFound)
+			app.Request(http.MethodGet, v.path+"/1", "").Error(http.StatusNotFound, v.expectedNotFound)
+		})
+	}
+}
+
+// TestUpdateSiswa_MergePatch runs patches in order against one siswa
+func TestUpdateSiswa_MergePatch(t *testing.T) {
+	app := apitest.New(t)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"2A","tingkat":2,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/wali_kelass", `{"nama":"Ibu Siti Rahman","alamat":"Jl. Merdeka No. 1, Bandung","telpon":"0812-0000-0001"}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/siswas", `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1,"alamat":"Jl. Sudirman No. 2, Bandung"}`).Data(http.StatusOK, nil)
+
+	type siswa struct {
+		Nama    string `json:"nama"`
+		IDKelas int    `json:"id_kelas"`
+		Tingkat int    `json:"tingkat"`
+		Alamat  string `json:"alamat"`
+	}
+
+	testScenarios := []struct {
+		scenarioName    string
+		contentType     string
+		body            string
+		expected        siswa
+		expectedStatus  int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "empty patch keeps siswa",
+			body:         `{}`,
+			expected:     siswa{Nama: "Budi Santoso", IDKelas: 1, Tingkat: 1, Alamat: "Jl. Sudirman No. 2, Bandung"},
+		},
+		{
+			scenarioName: "patch changes only fields that are present",
+			body:         `{"id_kelas":2,"tingkat":2}`,
+			expected:     siswa{Nama: "Budi Santoso", IDKelas: 2, Tingkat: 2, Alamat: "Jl. Sudirman No. 2, Bandung"},
+		},
+		{
+			scenarioName: "null clears alamat",
+			body:         `{"alamat":null}`,
+			expected:     siswa{Nama: "Budi Santoso", IDKelas: 2, Tingkat: 2},
+		},
+		{
+			scenarioName: "value is set",
+			body:         `{"alamat":"Jl. Dago No. 4, Bandung"}`,
+			expected:     siswa{Nama: "Budi Santoso", IDKelas: 2, Tingkat: 2, Alamat: "Jl. Dago No. 4, Bandung"},
+		},
+		{
+			scenarioName: "zero value clears alamat",
+			body:         `{"alamat":""}`,
+			expected:     siswa{Nama: "Budi Santoso", IDKelas: 2, Tingkat: 2},
+		},
+		{
+			scenarioName:    "null of required field",
+			body:            `{"nama":null}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedErrCode: apierror.SiswaNamaRequired,
+		},
+		{
+			scenarioName:    "zero value of required field",
+			body:            `{"tingkat":0}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedErrCode: apierror.SiswaTingkatRequired,
+		},
+		{
+			scenarioName: "application/json patch",
+			contentType:  echo.MIMEApplicationJSON,
+			body:         `{"nama":"Budi Santosa"}`,
+			expected:     siswa{Nama: "Budi Santosa", IDKelas: 2, Tingkat: 2},
+		},
+		{
+			scenarioName:    "patch that is not object",
+			body:            `[{"nama":"Budi"}]`,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedErrCode: apierror.RequestBindFailed,
+		},
+	}
+
+	for _, v := range testScenarios {
+		contentType := v.contentType
+		if contentType == "" {
+			contentType = optional.MIMEApplicationMergePatchJSON
+		}
+		res := app.RequestWithContentType(http.MethodPatch, "/siswas/1", contentType, v.body)
+
+		if v.expectedErrCode != "" {
+			res.Error(v.expectedStatus, v.expectedErrCode)
+			continue
+		}
+
+		updated := siswa{}
+		res.Data(http.StatusOK, &updated)
+		if updated != v.expected {
+			t.Errorf("%s: expect siswa %+v, but got %+v", v.scenarioName, v.expected, updated)
+			return
+		}
+	}
+}
+
+// TestPrecondition reads kelas with its ETag, then changes it with If-Match
+func TestPrecondition(t *testing.T) {
+	app := apitest.New(t)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+
+	res := app.Request(http.MethodGet, "/kelass/1", "")
+	res.Data(http.StatusOK, nil)
+	etag := res.Header.Get(precondition.HeaderETag)
+	if etag == "" {
+		t.Errorf("expect %s header, but got none", precondition.HeaderETag)
+		return
+	}
+
+	app.RequestWithHeader(http.MethodGet, "/kelass/1", http.Header{precondition.HeaderIfNoneMatch: {etag}}, "").Empty(http.StatusNotModified)
+
+	res = app.RequestWithHeader(http.MethodPatch, "/kelass/1", http.Header{precondition.HeaderIfMatch: {etag}}, `{"nama":"1B"}`)
+	res.Data(http.StatusOK, nil)
+	updatedETag := res.Header.Get(precondition.HeaderETag)
+	if updatedETag == "" || updatedETag == etag {
+		t.Errorf("expect new %s after update, but got %q", precondition.HeaderETag, updatedETag)
+		return
+	}
+
+	// client that read kelas before the update has stale ETag
+	app.RequestWithHeader(http.MethodPatch, "/kelass/1", http.Header{precondition.HeaderIfMatch: {etag}}, `{"nama":"1C"}`).Error(http.StatusPreconditionFailed, apierror.PreconditionFailed)
+	app.RequestWithHeader(http.MethodPut, "/kelass/1", http.Header{precondition.HeaderIfMatch: {etag}}, `{"nama":"1C","tingkat":1,"tahun_ajaran":2026}`).Error(http.StatusPreconditionFailed, apierror.PreconditionFailed)
+	app.RequestWithHeader(http.MethodDelete, "/kelass/1", http.Header{precondition.HeaderIfMatch: {etag}}, "").Error(http.StatusPreconditionFailed, apierror.PreconditionFailed)
+	app.RequestWithHeader(http.MethodGet, "/kelass/1", http.Header{precondition.HeaderIfNoneMatch: {etag}}, "").Data(http.StatusOK, nil)
+
+	app.RequestWithHeader(http.MethodDelete, "/kelass/1", http.Header{precondition.HeaderIfMatch: {updatedETag}}, "").Empty(http.StatusOK)
+}
+
+func TestPrecondition_RequireIfMatch(t *testing.T) {
+	app := apitest.NewWithConfig(t, api.Config{RequireIfMatch: true})
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+
+	app.Request(http.MethodPatch, "/kelass/1", `{"nama":"1B"}`).Error(http.StatusPreconditionRequired, apierror.PreconditionRequired)
+	app.Request(http.MethodDelete, "/kelass/1", "").Error(http.StatusPreconditionRequired, apierror.PreconditionRequired)
+	app.RequestWithHeader(http.MethodPatch, "/kelass/1", http.Header{precondition.HeaderIfMatch: {"*"}}, `{"nama":"1B"}`).Data(http.StatusOK, nil)
+}
+
+// TestSiswa_WaliKelas checks that wali kelas of siswa follows assignment of its kelas for tahun
+// ajaran of kelas
+func TestSiswa_WaliKelas(t *testing.T) {
+	app := apitest.New(t)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1B","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/wali_kelass", `{"nama":"Ibu Siti Rahman","alamat":"Jl. Merdeka No. 1, Bandung","telpon":"0812-0000-0001"}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/wali_kelass", `{"nama":"Bapak Agus Wijaya","alamat":"Jl. Dago No. 4, Bandung","telpon":"0812-0000-0003"}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/siswas", `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1,"alamat":"Jl. Sudirman No. 2, Bandung"}`).Data(http.StatusOK, nil)
+
+	type siswa struct {
+		IDWaliKelas *int `json:"id_wali_kelas"`
+	}
+	waliKelas1, waliKelas2 := 1, 2
+
+	testScenarios := []struct {
+		scenarioName        string
+		change              func()
+		expectedIDWaliKelas *int
+	}{
+		{
+			scenarioName: "kelas without wali kelas",
+		},
+		{
+			scenarioName: "wali kelas assigned to other kelas",
+			change: func() {
+				app.Request(http.MethodPost, "/kelas_wali_kelass", `{"id_kelas":2,"id_wali_kelas":2,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+			},
+		},
+		{
+			scenarioName: "wali kelas assigned to kelas",
+			change: func() {
+				app.Request(http.MethodPost, "/kelas_wali_kelass", `{"id_kelas":1,"id_wali_kelas":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+			},
+			expectedIDWaliKelas: &waliKelas1,
+		},
+		{
+			scenarioName: "assignment changes wali kelas",
+			change: func() {
+				app.Request(http.MethodPatch, "/kelas_wali_kelass/2", `{"id_wali_kelas":2}`).Data(http.StatusOK, nil)
+			},
+			expectedIDWaliKelas: &waliKelas2,
+		},
+		{
+			scenarioName: "assignment is deleted",
+			change: func() {
+				app.Request(http.MethodDelete, "/kelas_wali_kelass/2", "").Empty(http.StatusOK)
+			},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			if v.change != nil {
+				v.change()
+			}
+
+			got := siswa{}
+			app.Request(http.MethodGet, "/siswas/1", "").Data(http.StatusOK, &got)
+			if !reflect.DeepEqual(got.IDWaliKelas, v.expectedIDWaliKelas) {
+				t.Errorf("expect id wali kelas %v, but got %v", v.expectedIDWaliKelas, got.IDWaliKelas)
+				return
+			}
+
+			rows := []siswa{}
+			app.Request(http.MethodPost, "/siswas-grid", `{"skip":0,"pageSize":10}`).Grid(http.StatusOK, &rows)
+			if len(rows) != 1 || !reflect.DeepEqual(rows[0].IDWaliKelas, v.expectedIDWaliKelas) {
+				t.Errorf("expect grid with id wali kelas %v, but got %v", v.expectedIDWaliKelas, rows)
+				return
+			}
+		})
+	}
+}
+
+// TestGrid_Period checks that grids of kelas and siswa list tahun ajaran of active semester, of
+// semester parameter, or of every semester
+func TestGrid_Period(t *testing.T) {
+	app := apitest.New(t)
+
+	// grids of database without active semester are not scoped
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2025}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/kelass", `{"nama":"2A","tingkat":2,"tahun_ajaran":2026}`).Data(http.StatusOK, nil)
+	unscoped := []struct{}{}
+	app.Request(http.MethodPost, "/kelass-grid", `{"skip":0,"pageSize":10}`).Grid(http.StatusOK, &unscoped)
+	if len(unscoped) != 3 {
+		t.Errorf("expect 3 kelas without active semester, but got %d", len(unscoped))
+		return
+	}
+
+	app.Request(http.MethodPost, "/semesters", `{"tahun_ajaran":2025,"semester":"genap","mulai":"2026-01-01T00:00:00Z","akhir":"2026-06-30T00:00:00Z"}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/semesters", `{"tahun_ajaran":2026,"semester":"ganjil","mulai":"2026-07-01T00:00:00Z","akhir":"2026-12-31T00:00:00Z","aktif":true}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/siswas", `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1,"alamat":"Jl. Sudirman No. 2, Bandung"}`).Data(http.StatusOK, nil)
+	app.Request(http.MethodPost, "/siswas", `{"nama":"Budi Santoso","id_kelas":3,"tingkat":2,"alamat":"Jl. Sudirman No. 2, Bandung"}`).Data(http.StatusOK, nil)
+
+	type row struct {
+		ID int `json:"id"`
+	}
+
+	testScenarios := []struct {
+		scenarioName string
+		path         string
+		body         string
+		expectedIDs  []int
+	}{
+		{
+			scenarioName: "kelas of active semester",
+			path:         "/kelass-grid",
+			body:         `{"skip":0,"pageSize":10}`,
+			expectedIDs:  []int{2, 3},
+		},
+		{
+			scenarioName: "kelas of active semester and filters of request",
+			path:         "/kelass-grid",
+			body:         `{"skip":0,"pageSize":10,"filter":{"logic":"or","filters":[{"field":"nama","operator":"eq","value":"1A"},{"field":"tingkat","operator":"eq","value":"3"}]}}`,
+			expectedIDs:  []int{2},
+		},
+		{
+			scenarioName: "kelas of semester parameter",
+			path:         "/kelass-grid?semester=1",
+			body:         `{"skip":0,"pageSize":10}`,
+			expectedIDs:  []int{1},
+		},
+		{
+			scenarioName: "kelas of tahun ajaran filter",
+			path:         "/kelass-grid",
+			body:         `{"skip":0,"pageSize":10,"filter":{"logic":"and","filters":[{"field":"tahun_ajaran","operator":"eq","value":"2025"}]}}`,
+			expectedIDs:  []int{1},
+		},
+		{
+			scenarioName: "kelas of every semester",
+			path:         "/kelass-grid?semester=all",
+			body:         `{"skip":0,"pageSize":10}`,
+			expectedIDs:  []int{1, 2, 3},
+		},
+		{
+			scenarioName: "siswa of active semester",
+			path:         "/siswas-grid",
+			body:         `{"skip":0,"pageSize":10}`,
+			expectedIDs:  []int{2},
+		},
+		{
+			scenarioName: "siswa of semester parameter",
+			path:         "/siswas-grid?semester=1",
+			body:         `{"skip":0,"pageSize":10}`,
+			expectedIDs:  []int{1},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			rows := []row{}
+			app.Request(http.MethodPost, v.path, v.body).Grid(http.StatusOK, &rows)
+
+			ids := []int{}
+			for _, r := range rows {
+				ids = append(ids, r.ID)
+			}
+			if !reflect.DeepEqual(ids, v.expectedIDs) {
+				t.Errorf("expect ids %v, but got %v", v.expectedIDs, ids)
+				return
+			}
+		})
+	}
+
+	app.Request(http.MethodPost, "/kelass-grid?semester=abc", `{"skip":0,"pageSize":10}`).Error(http.StatusUnprocessableEntity, apierror.RequestInvalid)
+	app.Request(http.MethodPost, "/kelass-grid?semester=9", `{"skip":0,"pageSize":10}`).Error(http.StatusNotFound, apierror.SemesterNotFound)
+}
+
+func TestCreateSiswa_ForeignKey(t *testing.T) {
+	app := apitest.New(t)
+
+	app.Request(http.MethodPost, "/siswas", `{"nama":"Budi Santoso","id_kelas":1,"tingkat":1,"alamat":"Jl. Sudirman No. 2, Bandung"}`).Error(http.StatusBadRequest, apierror.SiswaKelasNotFound)
+}
+
+func TestGrid_UnknownColumn(t *testing.T) {
+	app := apitest.New(t)
+
+	// column is put into SQL, so PostgreSQL fails the same way
+	app.Request(http.MethodPost, "/kelass-grid", `{"skip":0,"pageSize":10,"sort":[{"field":"umur","dir":"asc"}]}`).Error(http.StatusInternalServerError, apierror.DatabaseError)
+}
+
+func TestErrorHandler_RequestID(t *testing.T) {
+	app := apitest.New(t)
+
+	res := app.Request(http.MethodGet, "/kelass/1", "")
+	res.Error(http.StatusNotFound, apierror.KelasNotFound)
+	if res.Header.Get(echo.HeaderXRequestID) == "" {
+		t.Errorf("expect %s header, but got none", echo.HeaderXRequestID)
+		return
+	}
+}
+
+// TestNewServices checks that database store sets every service, so main registers every handler,
+// and that memory store leaves out services written only for database
+func TestNewServices(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		store        service.Store
+		expectedNil  []string
+	}{
+		{
+			scenarioName: "database store",
+			store:        service.NewDBStore(nil),
+			expectedNil:  []string{},
+		},
+		{
+			scenarioName: "memory store",
+			store:        memory.New(),
+			expectedNil:  []string{"KenaikanKelas", "RiwayatKelas", "Kehadiran"},
+		},
+	}
+
+	for _, v := range testScenarios {
+		services := reflect.ValueOf(api.NewServices(v.store))
+
+		gotNil := []string{}
+		for i := 0; i < services.NumField(); i++ {
+			if services.Field(i).IsNil() {
+				gotNil = append(gotNil, services.Type().Field(i).Name)
+			}
+		}
+		if !reflect.DeepEqual(gotNil, v.expectedNil) {
+			t.Errorf("%s: expect services %v not set, but got %v", v.scenarioName, v.expectedNil, gotNil)
+			return
+		}
+	}
+}
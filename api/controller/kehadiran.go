@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/openapi"
+	"github.com/syukur91/ischool-monitor/pkg/period"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+// KehadiranHandler reports kehadiran of siswa in semester of request
+type KehadiranHandler struct {
+	KehadiranService service.KehadiranRepository
+}
+
+// SetRoutes ...
+func (h *KehadiranHandler) SetRoutes(r *echo.Group) {
+	r.GET("/rekap_kehadiran", h.rekapKehadiran)
+
+	openapi.Describe(h.rekapKehadiran, openapi.Operation{Summary: "Count kehadiran by status in active semester, in ?semester=<id>, or in every semester with ?semester=all", Tag: "Kehadiran", Response: schema.RekapKehadiranResponse{}, Query: []openapi.Parameter{openapi.QueryParam(period.Param, period.Description)}})
+}
+
+func (h *KehadiranHandler) rekapKehadiran(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	p, err := period.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rekapResponse, err := h.KehadiranService.RekapKehadiran(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, rekapResponse)
+}
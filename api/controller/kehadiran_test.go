@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/middleware"
+	"github.com/syukur91/ischool-monitor/pkg/period"
+)
+
+// kehadiranStub counts one alfa in every semester, and echoes semester of period back
+type kehadiranStub struct{}
+
+func (kehadiranStub) RekapKehadiran(ctx context.Context, p *period.Period) (*schema.RekapKehadiranResponse, error) {
+	rekap := &schema.RekapKehadiranResponse{Alfa: 1}
+	if p != nil {
+		rekap.IDSemester = &p.SemesterID
+	}
+	return rekap, nil
+}
+
+// semesterStub resolves semester 2 and active semester 1, and fails for the others
+func semesterStub(ctx context.Context, id string) (*period.Period, error) {
+	switch id {
+	case "":
+		return &period.Period{SemesterID: 1, TahunAjaran: 2026, Mulai: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Akhir: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)}, nil
+	case "2":
+		return &period.Period{SemesterID: 2, TahunAjaran: 2026, Mulai: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), Akhir: time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)}, nil
+	}
+	return nil, apierror.NewError(http.StatusNotFound, apierror.SemesterNotFound, "Semester with id: "+id+" is not exists", errors.New("getsemester: semester is not exists"))
+}
+
+func TestKehadiranHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler(zap.NewNop())
+	e.Use(middleware.PeriodWithConfig(middleware.PeriodConfig{Resolver: semesterStub}))
+
+	h := &KehadiranHandler{KehadiranService: kehadiranStub{}}
+	h.SetRoutes(e.Group("/:tenant"))
+
+	testScenarios := []struct {
+		scenarioName   string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			scenarioName:   "rekap of active semester",
+			path:           "/demo/rekap_kehadiran",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id_semester":1`,
+		},
+		{
+			scenarioName:   "rekap of semester of request",
+			path:           "/demo/rekap_kehadiran?semester=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id_semester":2`,
+		},
+		{
+			scenarioName:   "rekap of every semester",
+			path:           "/demo/rekap_kehadiran?semester=all",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id_semester":null`,
+		},
+		{
+			scenarioName:   "rekap of missing semester",
+			path:           "/demo/rekap_kehadiran?semester=3",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"SEMESTER_NOT_FOUND"`,
+		},
+	}
+
+	for _, v := range testScenarios {
+		req := httptest.NewRequest(http.MethodGet, v.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != v.expectedStatus {
+			t.Errorf("%s: expect status %d, but got %d: %s", v.scenarioName, v.expectedStatus, rec.Code, rec.Body.String())
+			return
+		}
+
+		if !strings.Contains(rec.Body.String(), v.expectedBody) {
+			t.Errorf("%s: expect body containing %s, but got %s", v.scenarioName, v.expectedBody, rec.Body.String())
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"github.com/syukur91/ischool-monitor/pkg/middleware"
+	"github.com/syukur91/ischool-monitor/service/memory"
+)
+
+type testValidator struct {
+	validator *validator.Validate
+}
+
+func (v *testValidator) Validate(i interface{}) error {
+	return v.validator.Struct(i)
+}
+
+// TestKelasHandler_Memory runs kelas routes in order against one in-memory store, without database
+func TestKelasHandler_Memory(t *testing.T) {
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+	e.HTTPErrorHandler = middleware.ErrorHandler(zap.NewNop())
+
+	h := &KelasHandler{KelasService: memory.New().Kelas()}
+	h.SetRoutes(e.Group("/:tenant"))
+
+	testScenarios := []struct {
+		scenarioName   string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			scenarioName:   "create 1A",
+			method:         http.MethodPost,
+			path:           "/demo/kelass",
+			body:           `{"nama":"1A","tingkat":1,"tahun_ajaran":2026}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":1,"nama":"1A"`,
+		},
+		{
+			scenarioName:   "create 2A",
+			method:         http.MethodPost,
+			path:           "/demo/kelass",
+			body:           `{"nama":"2A","tingkat":2,"tahun_ajaran":2026}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":2,"nama":"2A"`,
+		},
+		{
+			scenarioName:   "create without nama",
+			method:         http.MethodPost,
+			path:           "/demo/kelass",
+			body:           `{"tingkat":3}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"REQUEST_INVALID"`,
+		},
+		{
+			scenarioName:   "get",
+			method:         http.MethodGet,
+			path:           "/demo/kelass/2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"nama":"2A","tingkat":2`,
+		},
+		{
+			scenarioName:   "grid with filter",
+			method:         http.MethodPost,
+			path:           "/demo/kelass-grid",
+			body:           `{"skip":0,"pageSize":10,"filter":{"logic":"and","filters":[{"field":"tingkat","operator":"eq","value":"1"}]}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"total":1`,
+		},
+		{
+			scenarioName:   "update",
+			method:         http.MethodPatch,
+			path:           "/demo/kelass/2",
+			body:           `{"nama":"2B"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"nama":"2B","tingkat":2`,
+		},
+		{
+			scenarioName:   "update required field to zero",
+			method:         http.MethodPatch,
+			path:           "/demo/kelass/2",
+			body:           `{"tingkat":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"code":"KELAS_TINGKAT_REQUIRED"`,
+		},
+		{
+			scenarioName:   "replace",
+			method:         http.MethodPut,
+			path:           "/demo/kelass/2",
+			body:           `{"nama":"2C","tingkat":3,"tahun_ajaran":2026}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"nama":"2C","tingkat":3`,
+		},
+		{
+			scenarioName:   "replace without tingkat",
+			method:         http.MethodPut,
+			path:           "/demo/kelass/2",
+			body:           `{"nama":"2C"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"REQUEST_INVALID"`,
+		},
+		{
+			scenarioName:   "delete",
+			method:         http.MethodDelete,
+			path:           "/demo/kelass/2",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			scenarioName:   "get deleted",
+			method:         http.MethodGet,
+			path:           "/demo/kelass/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"status":404`,
+		},
+	}
+
+	for _, v := range testScenarios {
+		req := httptest.NewRequest(v.method, v.path, strings.NewReader(v.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != v.expectedStatus {
+			t.Errorf("%s: expect status %d, but got %d: %s", v.scenarioName, v.expectedStatus, rec.Code, rec.Body.String())
+			return
+		}
+
+		if !strings.Contains(rec.Body.String(), v.expectedBody) {
+			t.Errorf("%s: expect body containing %s, but got %s", v.scenarioName, v.expectedBody, rec.Body.String())
+			return
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/labstack/echo"
+)
+
+// Hand-written part of Kelas_Wali_KelasHandler. Generated CRUD handlers live in kelas_wali_kelas_gen.go,
+// which is overwritten on every generate. This file is only created when missing.
+
+// setCustomRoutes registers routes that are not generated. Describe them with openapi.Describe
+// to list their request and response in openapi.json
+func (h *Kelas_Wali_KelasHandler) setCustomRoutes(r *echo.Group) {
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/openapi"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+// KenaikanKelasHandler previews, applies and undoes kenaikan kelas of tahun ajaran
+type KenaikanKelasHandler struct {
+	KenaikanKelasService service.KenaikanKelasRepository
+}
+
+// SetRoutes ...
+func (h *KenaikanKelasHandler) SetRoutes(r *echo.Group) {
+	r.POST("/kenaikan_kelass/preview", h.previewKenaikanKelas)
+	r.POST("/kenaikan_kelass", h.applyKenaikanKelas)
+	r.GET("/kenaikan_kelass/:id", h.getKenaikanKelas)
+	r.POST("/kenaikan_kelass/:id/undo", h.undoKenaikanKelas)
+
+	openapi.Describe(h.previewKenaikanKelas, openapi.Operation{Summary: "Preview hasil of every siswa of kenaikan kelas without saving it", Tag: "Kenaikan Kelas", Request: schema.KenaikanKelasRequest{}, Response: schema.KenaikanKelasResponse{}})
+	openapi.Describe(h.applyKenaikanKelas, openapi.Operation{Summary: "Apply kenaikan kelas, moving every siswa to its kelas tujuan in one transaction", Tag: "Kenaikan Kelas", Request: schema.KenaikanKelasRequest{}, Response: schema.KenaikanKelasResponse{}})
+	openapi.Describe(h.getKenaikanKelas, openapi.Operation{Summary: "Get kenaikan kelas with hasil of its siswa", Tag: "Kenaikan Kelas", Response: schema.KenaikanKelasResponse{}})
+	openapi.Describe(h.undoKenaikanKelas, openapi.Operation{Summary: "Undo kenaikan kelas, moving its siswa back to kelas asal. Fails after undo period", Tag: "Kenaikan Kelas", Response: schema.KenaikanKelasResponse{}})
+}
+
+func (h *KenaikanKelasHandler) previewKenaikanKelas(c echo.Context) error {
+	request, err := h.bind(c, "previewKenaikanKelas")
+	if err != nil {
+		return err
+	}
+
+	previewResponse, err := h.KenaikanKelasService.PreviewKenaikanKelas(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, previewResponse)
+}
+
+func (h *KenaikanKelasHandler) applyKenaikanKelas(c echo.Context) error {
+	request, err := h.bind(c, "applyKenaikanKelas")
+	if err != nil {
+		return err
+	}
+
+	applyResponse, err := h.KenaikanKelasService.ApplyKenaikanKelas(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, applyResponse)
+}
+
+func (h *KenaikanKelasHandler) getKenaikanKelas(c echo.Context) error {
+	id := c.Param("id")
+
+	getResponse, err := h.KenaikanKelasService.GetKenaikanKelas(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, getResponse)
+}
+
+func (h *KenaikanKelasHandler) undoKenaikanKelas(c echo.Context) error {
+	id := c.Param("id")
+
+	undoResponse, err := h.KenaikanKelasService.UndoKenaikanKelas(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, undoResponse)
+}
+
+// bind returns kenaikan kelas request of body
+func (h *KenaikanKelasHandler) bind(c echo.Context, fn string) (*schema.KenaikanKelasRequest, error) {
+	request := new(schema.KenaikanKelasRequest)
+	err := c.Bind(request)
+	if err != nil {
+		return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.RequestBindFailed, "Failed to get kenaikan kelas data. Probably content-type is not match with actual body type", errors.New(fn+": Failed to get kenaikan kelas data"))
+	}
+
+	err = c.Validate(request)
+	if err != nil {
+		return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.RequestInvalid, "Kenaikan kelas data invalid. One or more required fields is not set", errors.New(fn+": invalid kenaikan kelas data"))
+	}
+
+	return request, nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/middleware"
+)
+
+// kenaikanKelasStub returns hasil of one siswa for every request, and applied kenaikan kelas with id 1
+type kenaikanKelasStub struct{}
+
+func (kenaikanKelasStub) PreviewKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (*schema.KenaikanKelasResponse, error) {
+	return &schema.KenaikanKelasResponse{
+		TahunAjaran:  request.TahunAjaran,
+		TingkatAkhir: request.TingkatAkhir,
+		Siswa:        []schema.KenaikanKelasSiswaResponse{{IDSiswa: 1, Nama: "Budi Santoso", IDKelasAsal: 1, TingkatAsal: 1, Hasil: "naik"}},
+	}, nil
+}
+
+func (s kenaikanKelasStub) ApplyKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (*schema.KenaikanKelasResponse, error) {
+	kenaikanKelas, _ := s.PreviewKenaikanKelas(ctx, request)
+	kenaikanKelas.ID = 1
+	return kenaikanKelas, nil
+}
+
+func (s kenaikanKelasStub) GetKenaikanKelas(ctx context.Context, id string) (*schema.KenaikanKelasResponse, error) {
+	if id != "1" {
+		return nil, apierror.NewError(http.StatusNotFound, apierror.KenaikanKelasNotFound, "Kenaikan kelas with id: "+id+" is not exists", errors.New("getkenaikankelas: kenaikan kelas is not exists"))
+	}
+	return s.ApplyKenaikanKelas(ctx, &schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6})
+}
+
+func (s kenaikanKelasStub) UndoKenaikanKelas(ctx context.Context, id string) (*schema.KenaikanKelasResponse, error) {
+	return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasUndoExpired, "Kenaikan kelas with id: "+id+" can only be undone until 2026-07-08T00:00:00Z", errors.New("undokenaikankelas: undo period is over"))
+}
+
+func TestKenaikanKelasHandler(t *testing.T) {
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+	e.HTTPErrorHandler = middleware.ErrorHandler(zap.NewNop())
+
+	h := &KenaikanKelasHandler{KenaikanKelasService: kenaikanKelasStub{}}
+	h.SetRoutes(e.Group("/:tenant"))
+
+	testScenarios := []struct {
+		scenarioName   string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			scenarioName:   "preview",
+			method:         http.MethodPost,
+			path:           "/demo/kenaikan_kelass/preview",
+			body:           `{"tahun_ajaran":2026,"tingkat_akhir":6,"kelas":[{"id_kelas":1,"id_kelas_tujuan":5}]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id_siswa":1,"nama":"Budi Santoso"`,
+		},
+		{
+			scenarioName:   "preview without tingkat akhir",
+			method:         http.MethodPost,
+			path:           "/demo/kenaikan_kelass/preview",
+			body:           `{"tahun_ajaran":2026}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"REQUEST_INVALID"`,
+		},
+		{
+			scenarioName:   "apply with siswa without hasil",
+			method:         http.MethodPost,
+			path:           "/demo/kenaikan_kelass",
+			body:           `{"tahun_ajaran":2026,"tingkat_akhir":6,"siswa":[{"id_siswa":1}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"REQUEST_INVALID"`,
+		},
+		{
+			scenarioName:   "apply",
+			method:         http.MethodPost,
+			path:           "/demo/kenaikan_kelass",
+			body:           `{"tahun_ajaran":2026,"tingkat_akhir":6,"siswa":[{"id_siswa":1,"hasil":"tinggal"}]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":1,"tahun_ajaran":2026`,
+		},
+		{
+			scenarioName:   "get",
+			method:         http.MethodGet,
+			path:           "/demo/kenaikan_kelass/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":1`,
+		},
+		{
+			scenarioName:   "get missing",
+			method:         http.MethodGet,
+			path:           "/demo/kenaikan_kelass/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"KENAIKAN_KELAS_NOT_FOUND"`,
+		},
+		{
+			scenarioName:   "undo after undo period",
+			method:         http.MethodPost,
+			path:           "/demo/kenaikan_kelass/1/undo",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"KENAIKAN_KELAS_UNDO_EXPIRED"`,
+		},
+	}
+
+	for _, v := range testScenarios {
+		req := httptest.NewRequest(v.method, v.path, strings.NewReader(v.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != v.expectedStatus {
+			t.Errorf("%s: expect status %d, but got %d: %s", v.scenarioName, v.expectedStatus, rec.Code, rec.Body.String())
+			return
+		}
+
+		if !strings.Contains(rec.Body.String(), v.expectedBody) {
+			t.Errorf("%s: expect body containing %s, but got %s", v.scenarioName, v.expectedBody, rec.Body.String())
+			return
+		}
+	}
+}
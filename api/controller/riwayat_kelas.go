@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/openapi"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+// RiwayatKelasHandler lists riwayat kelas of siswa and siswa of kelas on a date
+type RiwayatKelasHandler struct {
+	RiwayatKelasService service.RiwayatKelasRepository
+}
+
+// SetRoutes ...
+func (h *RiwayatKelasHandler) SetRoutes(r *echo.Group) {
+	r.GET("/siswas/:id/riwayat_kelas", h.listRiwayatKelas)
+	r.GET("/kelass/:id/siswas", h.listAnggotaKelas)
+
+	openapi.Describe(h.listRiwayatKelas, openapi.Operation{Summary: "List every kelas of siswa with its mulai and akhir, oldest first", Tag: "Riwayat Kelas", Response: []schema.RiwayatKelasResponse{}})
+	openapi.Describe(h.listAnggotaKelas, openapi.Operation{Summary: "List siswa that were in kelas on tanggal (YYYY-MM-DD), today by default", Tag: "Riwayat Kelas", Response: []schema.AnggotaKelasResponse{}, Query: []openapi.Parameter{openapi.QueryParam("tanggal", "Date as YYYY-MM-DD. Default today in database")}})
+}
+
+func (h *RiwayatKelasHandler) listRiwayatKelas(c echo.Context) error {
+	id := c.Param("id")
+
+	listResponse, err := h.RiwayatKelasService.ListRiwayatKelas(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, listResponse)
+}
+
+func (h *RiwayatKelasHandler) listAnggotaKelas(c echo.Context) error {
+	id := c.Param("id")
+
+	// without tanggal the service uses current date of database, not clock of this server
+	var tanggal *time.Time
+	if v := c.QueryParam("tanggal"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.RequestInvalid, "Tanggal "+v+" is invalid. Tanggal must be YYYY-MM-DD", errors.New("listAnggotaKelas: invalid tanggal "+v))
+		}
+		tanggal = &parsed
+	}
+
+	listResponse, err := h.RiwayatKelasService.ListAnggotaKelas(c.Request().Context(), id, tanggal)
+	if err != nil {
+		return err
+	}
+
+	return response.JSON(c, http.StatusOK, listResponse)
+}
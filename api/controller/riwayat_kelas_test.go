@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/middleware"
+)
+
+// riwayatKelasStub returns riwayat of siswa 1 in kelas 1, and that siswa as anggota of kelas 1 on
+// tanggal that is echoed back as mulai, or without mulai when tanggal is not set
+type riwayatKelasStub struct{}
+
+func (riwayatKelasStub) ListRiwayatKelas(ctx context.Context, idSiswa string) ([]schema.RiwayatKelasResponse, error) {
+	if idSiswa != "1" {
+		return nil, apierror.NewError(http.StatusNotFound, apierror.RiwayatKelasSiswaNotFound, "Siswa with id: "+idSiswa+" is not exists", errors.New("listriwayatkelas: siswa is not exists"))
+	}
+	return []schema.RiwayatKelasResponse{{ID: 1, IDSiswa: 1, IDKelas: 1, NamaKelas: "1A", Tingkat: 1, TahunAjaran: 2026}}, nil
+}
+
+func (riwayatKelasStub) ListAnggotaKelas(ctx context.Context, idKelas string, tanggal *time.Time) ([]schema.AnggotaKelasResponse, error) {
+	anggota := schema.AnggotaKelasResponse{IDSiswa: 1, Nama: "Budi Santoso"}
+	if tanggal != nil {
+		anggota.Mulai = *tanggal
+	}
+	return []schema.AnggotaKelasResponse{anggota}, nil
+}
+
+func TestRiwayatKelasHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler(zap.NewNop())
+
+	h := &RiwayatKelasHandler{RiwayatKelasService: riwayatKelasStub{}}
+	h.SetRoutes(e.Group("/:tenant"))
+
+	testScenarios := []struct {
+		scenarioName   string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			scenarioName:   "riwayat kelas of siswa",
+			path:           "/demo/siswas/1/riwayat_kelas",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id_kelas":1,"nama_kelas":"1A"`,
+		},
+		{
+			scenarioName:   "riwayat kelas of missing siswa",
+			path:           "/demo/siswas/2/riwayat_kelas",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"RIWAYAT_KELAS_SISWA_NOT_FOUND"`,
+		},
+		{
+			scenarioName:   "siswa of kelas on tanggal",
+			path:           "/demo/kelass/1/siswas?tanggal=2026-03-01",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"mulai":"2026-03-01T00:00:00Z"`,
+		},
+		{
+			scenarioName:   "siswa of kelas without tanggal",
+			path:           "/demo/kelass/1/siswas",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"mulai":"0001-01-01T00:00:00Z"`,
+		},
+		{
+			scenarioName:   "siswa of kelas on invalid tanggal",
+			path:           "/demo/kelass/1/siswas?tanggal=01-03-2026",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"REQUEST_INVALID"`,
+		},
+	}
+
+	for _, v := range testScenarios {
+		req := httptest.NewRequest(http.MethodGet, v.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != v.expectedStatus {
+			t.Errorf("%s: expect status %d, but got %d: %s", v.scenarioName, v.expectedStatus, rec.Code, rec.Body.String())
+			return
+		}
+
+		if !strings.Contains(rec.Body.String(), v.expectedBody) {
+			t.Errorf("%s: expect body containing %s, but got %s", v.scenarioName, v.expectedBody, rec.Body.String())
+			return
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package schema
+
+import (
+	"time"
+)
+
+// RekapKehadiranResponse counts kehadiran of siswa by status in a semester, or in every semester
+// when semester is not set
+type RekapKehadiranResponse struct {
+	IDSemester  *int       `json:"id_semester" db:"-"`
+	TahunAjaran *int       `json:"tahun_ajaran" db:"-"`
+	Mulai       *time.Time `json:"mulai" db:"-"`
+	Akhir       *time.Time `json:"akhir" db:"-"`
+	Sakit       int        `json:"sakit" db:"sakit"`
+	Izin        int        `json:"izin" db:"izin"`
+	Alfa        int        `json:"alfa" db:"alfa"`
+}
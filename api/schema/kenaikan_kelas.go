@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+)
+
+// KenaikanKelasRequest is plan of kenaikan kelas of siswa in kelas of tahun ajaran. Siswa of kelas
+// move to kelas tujuan of their kelas, in next tahun ajaran, except those in Siswa. Siswa of kelas
+// with tingkat akhir graduate.
+type KenaikanKelasRequest struct {
+	TahunAjaran  int                         `json:"tahun_ajaran" validate:"required"`
+	TingkatAkhir int                         `json:"tingkat_akhir" validate:"required"`
+	Kelas        []KenaikanKelasKelasRequest `json:"kelas" validate:"dive"`
+	Siswa        []KenaikanKelasSiswaRequest `json:"siswa" validate:"dive"`
+}
+
+// KenaikanKelasKelasRequest sets kelas tujuan of siswa of kelas that naik
+type KenaikanKelasKelasRequest struct {
+	IDKelas       int `json:"id_kelas" validate:"required"`
+	IDKelasTujuan int `json:"id_kelas_tujuan" validate:"required"`
+}
+
+// KenaikanKelasSiswaRequest overrides hasil of one siswa. Kelas tujuan is kelas tujuan of its
+// kelas when it is not set, and is not set for siswa that lulus.
+type KenaikanKelasSiswaRequest struct {
+	IDSiswa       int    `json:"id_siswa" validate:"required"`
+	Hasil         string `json:"hasil" validate:"required"`
+	IDKelasTujuan *int   `json:"id_kelas_tujuan"`
+}
+
+// KenaikanKelasResponse is kenaikan kelas, planned by preview or applied. Id and dates are not
+// set in preview.
+type KenaikanKelasResponse struct {
+	ID           int                          `json:"id" db:"id"`
+	TahunAjaran  int                          `json:"tahun_ajaran" db:"tahun_ajaran"`
+	TingkatAkhir int                          `json:"tingkat_akhir" db:"tingkat_akhir"`
+	CreatedAt    *time.Time                   `json:"created_at,omitempty" db:"created_at"`
+	UndoBefore   *time.Time                   `json:"undo_before,omitempty" db:"-"`
+	UndoneAt     *time.Time                   `json:"undone_at,omitempty" db:"undone_at"`
+	Siswa        []KenaikanKelasSiswaResponse `json:"siswa" db:"-"`
+}
+
+// KenaikanKelasSiswaResponse is hasil of one siswa, with kelas it leaves and kelas it moves to.
+// Kelas tujuan is not set for siswa that lulus, nor for siswa that has no kelas tujuan yet.
+type KenaikanKelasSiswaResponse struct {
+	IDSiswa       int    `json:"id_siswa" db:"id_siswa"`
+	Nama          string `json:"nama" db:"nama"`
+	IDKelasAsal   int    `json:"id_kelas_asal" db:"id_kelas_asal"`
+	TingkatAsal   int    `json:"tingkat_asal" db:"tingkat_asal"`
+	Hasil         string `json:"hasil" db:"hasil"`
+	IDKelasTujuan *int   `json:"id_kelas_tujuan" db:"id_kelas_tujuan"`
+	TingkatTujuan *int   `json:"tingkat_tujuan" db:"tingkat_tujuan"`
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"time"
+)
+
+// RiwayatKelasResponse is kelas of siswa from mulai until the day before akhir. Akhir is not set
+// while siswa is in kelas.
+type RiwayatKelasResponse struct {
+	ID          int        `json:"id" db:"id"`
+	IDSiswa     int        `json:"id_siswa" db:"id_siswa"`
+	IDKelas     int        `json:"id_kelas" db:"id_kelas"`
+	NamaKelas   string     `json:"nama_kelas" db:"nama_kelas"`
+	Tingkat     int        `json:"tingkat" db:"tingkat"`
+	TahunAjaran int        `json:"tahun_ajaran" db:"tahun_ajaran"`
+	Mulai       time.Time  `json:"mulai" db:"mulai"`
+	Akhir       *time.Time `json:"akhir" db:"akhir"`
+}
+
+// AnggotaKelasResponse is siswa that was in kelas on a day, with riwayat of that kelas
+type AnggotaKelasResponse struct {
+	IDSiswa int        `json:"id_siswa" db:"id_siswa"`
+	Nama    string     `json:"nama" db:"nama"`
+	Mulai   time.Time  `json:"mulai" db:"mulai"`
+	Akhir   *time.Time `json:"akhir" db:"akhir"`
+}
@@ -0,0 +1,110 @@
+// Command gen generates schema, service and controller of models listed in manifest
+// (template/models.yaml by default) from their tables. Run it from any directory in the repository:
+//
+//	go run ./cmd/gen                      # generate every model
+//	go run ./cmd/gen -model siswa         # generate one model
+//	go run ./cmd/gen -dry-run             # print diff, write nothing
+//	go run ./cmd/gen -templates ./my-tmpl # use other templates
+//	go run ./cmd/gen -db "$DB_CONNECTION_STR" # read columns from information_schema
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/syukur91/ischool-monitor/pkg/codegen"
+)
+
+func main() {
+	manifestPath := flag.String("manifest", "", "model manifest, YAML or JSON. Default is "+codegen.DefaultManifest+" in repository root")
+	modelName := flag.String("model", "", "generate only this model, by model or lower case name")
+	templateDir := flag.String("templates", "", "template directory. Default is templates of manifest")
+	dryRun := flag.Bool("dry-run", false, "print unified diff of changes instead of writing files")
+	dbConnection := flag.String("db", "", "database connection string, read columns from information_schema instead of migrations")
+	dbSchema := flag.String("schema", "public", "database schema of the tables")
+	flag.Parse()
+
+	log.SetFlags(0)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	root, err := codegen.FindRoot(cwd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *manifestPath == "" {
+		*manifestPath = filepath.Join(root, codegen.DefaultManifest)
+	}
+	manifest, err := codegen.LoadManifest(*manifestPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// templates given in command line are relative to working directory
+	if *templateDir != "" {
+		*templateDir, err = filepath.Abs(*templateDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	g := &codegen.Generator{
+		Root:        root,
+		Manifest:    manifest,
+		TemplateDir: *templateDir,
+		DBSchema:    *dbSchema,
+	}
+
+	if *dbConnection != "" {
+		g.DB, err = sqlx.Connect("postgres", *dbConnection)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer g.DB.Close()
+	}
+
+	models := manifest.Models
+	if *modelName != "" {
+		m, err := manifest.Find(*modelName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		models = []codegen.Model{*m}
+	}
+
+	ctx := context.Background()
+	for _, m := range models {
+		files, err := g.Render(ctx, m)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if *dryRun {
+			diff, err := g.Diff(files)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Print(diff)
+			continue
+		}
+
+		written, err := g.Write(files)
+		for _, path := range written {
+			log.Printf("%s: wrote %s", m.Model, path)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -1,26 +1,31 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
 	"math/rand"
-	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/XSAM/otelsql"
 	"github.com/jmoiron/sqlx"
-	"github.com/labstack/echo"
-	"github.com/labstack/echo/middleware"
 	_ "github.com/lib/pq"
-	"github.com/syukur91/ischool-monitor/api/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/syukur91/ischool-monitor/api"
 	"github.com/syukur91/ischool-monitor/service"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 
 	"github.com/arifsetiawan/go-common/env"
-	Middleware "github.com/syukur91/ischool-monitor/pkg/middleware"
-	"gopkg.in/go-playground/validator.v9"
+	"github.com/syukur91/ischool-monitor/pkg/metrics"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
 )
 
 func init() {
@@ -28,19 +33,51 @@ func init() {
 	rand.Seed(time.Now().Unix())
 }
 
-type (
-	// CustomValidator is
-	CustomValidator struct {
-		validator *validator.Validate
+// version is set at build time with -ldflags "-X main.version=1.2.3". Default is VERSION in environment
+var version = ""
+
+const usage = `Usage: ischool-monitor [command]
+
+Commands:
+  serve [-migrate]     start API server. Default command
+  migrate up           apply every pending migration
+  migrate down [N]     revert last N migrations, default 1
+  migrate to N         migrate up or down to version N, 0 reverts everything
+  migrate status       list migrations and whether they are applied
+  seed [-reset]        fill database with a deterministic demo school
+  version              print version of binary and its latest migration
+`
+
+func main() {
+	// no command keeps serving, as before commands were added
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	if version == "" {
+		version = env.Getenv("VERSION", "0.1.0")
 	}
-)
 
-// Validate is
-func (cv *CustomValidator) Validate(i interface{}) error {
-	return cv.validator.Struct(i)
+	switch command {
+	case "serve":
+		serve(args)
+	case "migrate":
+		migrateCommand(args)
+	case "seed":
+		seedCommand(args)
+	case "version":
+		versionCommand()
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
 }
 
-func main() {
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	migrateOnStart := flags.Bool("migrate", env.Getenv("MIGRATE_ON_START", "false") == "true", "apply pending migrations before serving. Default is MIGRATE_ON_START in environment")
+	flags.Parse(args)
 
 	rawJSON := []byte(`{
 		"level": "debug",
@@ -52,7 +89,7 @@ func main() {
 		"initialFields": {
 			"app_name": "` + env.Getenv("APP_NAME", "ischool-monitor") + `",
 			"type":"` + env.Getenv("APP_TYPE", "api") + `",
-			"version":"` + env.Getenv("VERSION", "0.1.0") + `"
+			"version":"` + version + `"
 		},
 		"encoderConfig": {
 		  "messageKey": "message",
@@ -73,63 +110,99 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// @
+	// initialize tracing
+	sampleRatio, err := strconv.ParseFloat(env.Getenv("TRACE_SAMPLE_RATIO", "1"), 64)
+	if err != nil {
+		log.Fatalf("Invalid TRACE_SAMPLE_RATIO: %v\n", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(tracing.Config{
+		ServiceName: env.Getenv("APP_NAME", "ischool-monitor"),
+		Version:     version,
+		Exporter:    env.Getenv("TRACE_EXPORTER", tracing.ExporterNone),
+		FilePath:    env.Getenv("TRACE_FILE", "traces.json"),
+		SampleRatio: sampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v\n", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownTracing(ctx)
+	}()
+
 	// @
 	// initialize database connection
 	if len(os.Getenv("DB_CONNECTION_STR")) == 0 {
 		log.Fatalf("Database connection string is not set. Set DB_CONNECTION_STR in environment\n")
 	}
 
-	db, err := sqlx.Connect(env.Getenv("DB_DRIVER", "postgres"), os.Getenv("DB_CONNECTION_STR"))
+	// every statement gets its own span under the service span
+	dbDriver := env.Getenv("DB_DRIVER", "postgres")
+	sqlDB, err := otelsql.Open(dbDriver, os.Getenv("DB_CONNECTION_STR"), otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		log.Fatalf("Failed to make database connection: %v\n", err)
 	}
+	db := sqlx.NewDb(sqlDB, dbDriver)
+	if err := db.Ping(); err != nil {
+		log.Fatalf("Failed to make database connection: %v\n", err)
+	}
 	defer db.Close()
 
 	// @
-	// Initialize echo
-	e := echo.New()
-	e.HideBanner = true
-	e.HidePort = true
-	e.Validator = &CustomValidator{validator: validator.New()}
-	e.HTTPErrorHandler = Middleware.ErrorHandler(logger)
-
-	loggerConfig := Middleware.LoggerConfig{
-		Skipper: func(c echo.Context) bool {
-			if strings.Contains(c.Request().RequestURI, "/public/") ||
-				strings.Contains(c.Request().RequestURI, "favicon") ||
-				strings.Contains(c.Request().RequestURI, "/js/") {
-				return true
-			}
-			return false
-		},
-		AppName: "ischool-monitor",
-		Logger:  logger,
+	// migrate on start. Replicas starting together wait for each other on advisory lock
+	if *migrateOnStart {
+		err = runMigrations(db.DB, logger)
+		if err != nil {
+			log.Fatalf("Failed to migrate database: %v\n", err)
+		}
 	}
 
-	e.Use(Middleware.LoggerWithConfig(loggerConfig))
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	queryTimeout, err := time.ParseDuration(env.Getenv("QUERY_TIMEOUT", "10s"))
+	if err != nil {
+		log.Fatalf("Invalid QUERY_TIMEOUT: %v\n", err)
+	}
+	gridQueryTimeout, err := time.ParseDuration(env.Getenv("GRID_QUERY_TIMEOUT", "30s"))
+	if err != nil {
+		log.Fatalf("Invalid GRID_QUERY_TIMEOUT: %v\n", err)
+	}
 
 	// @
 	// Create services
-	mataPelajaranService := service.NewMata_PelajaranService(db)
+	// services of handlers are built by api.NewServices, the same way api tests build them in memory
+	services := api.NewServices(service.NewDBStore(db))
+	statsService := service.NewStatsService(db)
 
 	// @
-	// Routes
-	r := e.Group("/:tenant")
-
-	// Mandatory hello world
-	r.GET("/hello", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Hello "+c.Param("tenant")+"! This is API version: "+os.Getenv("VERSION"))
-	})
+	// Metrics
+	prometheus.MustRegister(
+		collectors.NewDBStatsCollector(db.DB, env.Getenv("DB_NAME", "school")),
+		metrics.NewBusinessCollector("ischool", statsService, logger),
+	)
+
+	// tenants that label request metrics, comma separated
+	var tenants []string
+	if v := env.Getenv("METRICS_TENANTS", ""); v != "" {
+		tenants = strings.Split(v, ",")
+	}
 
 	// @
-	// Handlers
-	// Products API
-	mataPelajaranHandler := &controller.Mata_PelajaranHandler{
-		Mata_PelajaranService: mataPelajaranService,
-	}
-	mataPelajaranHandler.SetRoutes(r)
+	// Initialize echo with routes of handlers
+	e := api.New(api.Config{
+		Logger:    logger,
+		Version:   version,
+		LogBody:   env.Getenv("LOG_BODY", "false") == "true",
+		LogHeader: env.Getenv("LOG_HEADER", "false") == "true",
+
+		QueryTimeout:     queryTimeout,
+		GridQueryTimeout: gridQueryTimeout,
+
+		RequireIfMatch: env.Getenv("REQUIRE_IF_MATCH", "false") == "true",
+
+		Tenants: tenants,
+	}, services)
 
 	// @
 	// Start app
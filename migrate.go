@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/arifsetiawan/go-common/env"
+	"github.com/syukur91/ischool-monitor/migration"
+	"github.com/syukur91/ischool-monitor/pkg/migrate"
+)
+
+// migrateCommand runs migrate subcommand with migrations embedded in binary
+func migrateCommand(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dbConnection := flags.String("db", os.Getenv("DB_CONNECTION_STR"), "database connection string. Default is DB_CONNECTION_STR in environment")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage+"\nFlags of migrate:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if *dbConnection == "" {
+		log.Fatalf("Database connection string is not set. Set DB_CONNECTION_STR in environment or pass -db\n")
+	}
+
+	db, err := sql.Open(env.Getenv("DB_DRIVER", "postgres"), *dbConnection)
+	if err != nil {
+		log.Fatalf("Failed to make database connection: %v\n", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, migration.FS)
+	if err != nil {
+		log.Fatalf("Failed to read migrations: %v\n", err)
+	}
+	migrator.Log = func(direction string, m migrate.Migration) {
+		log.Printf("%s %d_%s\n", direction, m.Version, m.Title)
+	}
+
+	ctx := context.Background()
+	switch flags.Arg(0) {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		n := 1
+		if flags.NArg() > 1 {
+			n, err = strconv.Atoi(flags.Arg(1))
+			if err != nil || n < 1 {
+				log.Fatalf("Number of migrations to revert must be a positive number, got %s\n", flags.Arg(1))
+			}
+		}
+		err = migrator.Down(ctx, n)
+	case "to":
+		if flags.NArg() < 2 {
+			log.Fatalf("Version is not set. Usage: migrate to N\n")
+		}
+		version, perr := strconv.ParseInt(flags.Arg(1), 10, 64)
+		if perr != nil {
+			log.Fatalf("Version must be a number, got %s\n", flags.Arg(1))
+		}
+		err = migrator.To(ctx, version)
+	case "status":
+		err = printStatus(ctx, migrator)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Failed to migrate database: %v\n", err)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	current, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range status {
+		applied := "pending"
+		if s.Applied {
+			applied = "applied"
+		}
+		fmt.Printf("%-8s %d_%s\n", applied, s.Version, s.Title)
+	}
+
+	state := ""
+	if dirty {
+		state = " (dirty)"
+	}
+	fmt.Printf("version %d%s, latest %d\n", current, state, migrator.Latest())
+
+	return nil
+}
+
+// runMigrations applies pending migrations before serving
+func runMigrations(db *sql.DB, logger *zap.Logger) error {
+	migrator, err := migrate.New(db, migration.FS)
+	if err != nil {
+		return err
+	}
+	migrator.Log = func(direction string, m migrate.Migration) {
+		logger.Info("Migration applied", zap.String("direction", direction), zap.Int64("version", m.Version), zap.String("title", m.Title))
+	}
+
+	return migrator.Up(context.Background())
+}
+
+func versionCommand() {
+	latest := "unknown"
+	migrator, err := migrate.New(nil, migration.FS)
+	if err == nil {
+		latest = strconv.FormatInt(migrator.Latest(), 10)
+	}
+
+	fmt.Printf("ischool-monitor %s, migrations %s\n", version, latest)
+}
@@ -0,0 +1,22 @@
+--- Wali kelas of siswa is wali kelas of its kelas in latest tahun ajaran. It fails when kelas of
+--- siswa never had wali kelas.
+ALTER TABLE public.siswa
+    ADD COLUMN id_wali_kelas int;
+
+UPDATE public.siswa SET id_wali_kelas = (
+    SELECT k.id_wali_kelas
+    FROM public.kelas_wali_kelas k
+    WHERE k.id_kelas = siswa.id_kelas AND k.deleted_at IS NULL
+    ORDER BY k.tahun_ajaran DESC
+    LIMIT 1
+);
+
+ALTER TABLE public.siswa
+    ALTER COLUMN id_wali_kelas SET NOT NULL;
+
+ALTER TABLE ONLY public.siswa
+    ADD CONSTRAINT wali_kelas_siswa_id_wali_kelas_foreign FOREIGN KEY (id_wali_kelas) REFERENCES public.wali_kelas(id);
+
+DROP TABLE public.kelas_wali_kelas CASCADE;
+
+DROP FUNCTION public.tahun_ajaran(date);
@@ -0,0 +1,54 @@
+--- Tahun Ajaran
+--- tahun_ajaran returns tahun ajaran of day by its first year. Tahun ajaran starts in July,
+--- so 2026 is Tahun Ajaran 2026/2027.
+CREATE FUNCTION public.tahun_ajaran(day date) RETURNS int AS $$
+    SELECT CASE WHEN extract(month FROM day) >= 7
+        THEN extract(year FROM day)::int
+        ELSE extract(year FROM day)::int - 1
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+
+--- Kelas Wali Kelas
+--- Kelas Wali Kelas objek wali kelas yang ditugaskan ke kelas pada satu tahun ajaran.
+CREATE TABLE public.kelas_wali_kelas (
+    id int GENERATED BY DEFAULT AS IDENTITY,
+    id_kelas int NOT NULL,
+    id_wali_kelas int NOT NULL,
+    tahun_ajaran int NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE public.kelas_wali_kelas OWNER TO school;
+
+ALTER TABLE ONLY public.kelas_wali_kelas
+    ADD CONSTRAINT kelas_wali_kelas_pkey PRIMARY KEY (id);
+
+--- deleted assignment doesn't keep kelas from getting another wali kelas in that tahun ajaran
+CREATE UNIQUE INDEX kelas_wali_kelas_id_kelas_tahun_ajaran_unique ON public.kelas_wali_kelas (id_kelas, tahun_ajaran) WHERE deleted_at IS NULL;
+
+ALTER TABLE ONLY public.kelas_wali_kelas
+    ADD CONSTRAINT kelas_kelas_wali_kelas_id_kelas_foreign FOREIGN KEY (id_kelas) REFERENCES public.kelas(id);
+
+ALTER TABLE ONLY public.kelas_wali_kelas
+    ADD CONSTRAINT wali_kelas_kelas_wali_kelas_id_wali_kelas_foreign FOREIGN KEY (id_wali_kelas) REFERENCES public.wali_kelas(id);
+
+
+--- Backfill: wali kelas of most siswa of kelas is assigned to it in current tahun ajaran
+INSERT INTO public.kelas_wali_kelas (id_kelas, id_wali_kelas, tahun_ajaran)
+SELECT DISTINCT ON (id_kelas) id_kelas, id_wali_kelas, public.tahun_ajaran(current_date)
+FROM public.siswa
+WHERE deleted_at IS NULL
+GROUP BY id_kelas, id_wali_kelas
+ORDER BY id_kelas, count(*) DESC, id_wali_kelas;
+
+
+--- Siswa
+--- Wali kelas of siswa is wali kelas of its kelas.
+ALTER TABLE ONLY public.siswa
+    DROP CONSTRAINT wali_kelas_siswa_id_wali_kelas_foreign;
+
+ALTER TABLE public.siswa
+    DROP COLUMN id_wali_kelas;
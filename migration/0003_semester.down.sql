@@ -0,0 +1,6 @@
+ALTER TABLE public.kelas
+    DROP COLUMN tahun_ajaran;
+
+DROP TABLE public.semester CASCADE;
+
+DROP TYPE public.semester_type;
@@ -0,0 +1,58 @@
+---
+--- Enumerations
+--- 
+
+
+CREATE TYPE public.semester_type AS ENUM (
+    'ganjil',
+    'genap'
+);
+
+
+--- Semester
+--- Semester objek semester pada satu tahun ajaran. Satu semester aktif, yang dipakai list dan
+--- grid bila semester tidak dipilih.
+CREATE TABLE public.semester (
+    id int GENERATED BY DEFAULT AS IDENTITY,
+    tahun_ajaran int NOT NULL,
+    semester semester_type NOT NULL,
+    mulai date NOT NULL,
+    akhir date NOT NULL,
+    aktif boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE public.semester OWNER TO school;
+
+ALTER TABLE ONLY public.semester
+    ADD CONSTRAINT semester_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.semester
+    ADD CONSTRAINT semester_tahun_ajaran_semester_unique UNIQUE (tahun_ajaran, semester);
+
+--- one active semester per database. Tables have no tenant column, :tenant of paths doesn't
+--- partition rows, so a tenant that needs its own active semester needs its own database.
+CREATE UNIQUE INDEX semester_aktif_unique ON public.semester (aktif) WHERE aktif;
+
+
+--- Backfill: semesters of current tahun ajaran, the one of current date is active
+INSERT INTO public.semester (tahun_ajaran, semester, mulai, akhir, aktif)
+SELECT t.tahun, s.semester, s.mulai, s.akhir, current_date BETWEEN s.mulai AND s.akhir
+FROM (SELECT public.tahun_ajaran(current_date) AS tahun) t
+CROSS JOIN LATERAL (VALUES
+    ('ganjil'::public.semester_type, make_date(t.tahun, 7, 1), make_date(t.tahun, 12, 31)),
+    ('genap'::public.semester_type, make_date(t.tahun + 1, 1, 1), make_date(t.tahun + 1, 6, 30))
+) AS s (semester, mulai, akhir);
+
+
+--- Kelas
+--- Kelas belongs to one tahun ajaran. Existing kelas belong to current one.
+ALTER TABLE public.kelas
+    ADD COLUMN tahun_ajaran int;
+
+UPDATE public.kelas SET tahun_ajaran = public.tahun_ajaran(current_date);
+
+ALTER TABLE public.kelas
+    ALTER COLUMN tahun_ajaran SET NOT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE public.kenaikan_kelas_siswa CASCADE;
+
+DROP TABLE public.kenaikan_kelas CASCADE;
+
+DROP TYPE public.kenaikan_type;
@@ -0,0 +1,69 @@
+---
+--- Enumerations
+--- 
+
+
+CREATE TYPE public.kenaikan_type AS ENUM (
+    'naik',
+    'tinggal',
+    'lulus'
+);
+
+
+--- Kenaikan Kelas
+--- Kenaikan kelas objek kenaikan kelas siswa dari satu tahun ajaran ke tahun ajaran berikutnya.
+--- Kenaikan yang dibatalkan punya undone_at.
+CREATE TABLE public.kenaikan_kelas (
+    id int GENERATED BY DEFAULT AS IDENTITY,
+    tahun_ajaran int NOT NULL,
+    tingkat_akhir int NOT NULL,
+    undone_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE public.kenaikan_kelas OWNER TO school;
+
+ALTER TABLE ONLY public.kenaikan_kelas
+    ADD CONSTRAINT kenaikan_kelas_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX kenaikan_kelas_tahun_ajaran_unique ON public.kenaikan_kelas (tahun_ajaran) WHERE undone_at IS NULL AND deleted_at IS NULL;
+
+
+--- Kenaikan Kelas Siswa
+--- Kenaikan Kelas Siswa objek hasil kenaikan kelas satu siswa, dengan kelas asal dan tujuannya.
+--- Siswa yang lulus tidak punya kelas tujuan.
+CREATE TABLE public.kenaikan_kelas_siswa (
+    id int GENERATED BY DEFAULT AS IDENTITY,
+    id_kenaikan_kelas int NOT NULL,
+    id_siswa int NOT NULL,
+    id_kelas_asal int NOT NULL,
+    tingkat_asal int NOT NULL,
+    hasil kenaikan_type NOT NULL,
+    id_kelas_tujuan int,
+    tingkat_tujuan int,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE public.kenaikan_kelas_siswa OWNER TO school;
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT kenaikan_kelas_siswa_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT kenaikan_kelas_siswa_id_kenaikan_kelas_id_siswa_unique UNIQUE (id_kenaikan_kelas, id_siswa);
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT kenaikan_kelas_kenaikan_kelas_siswa_id_kenaikan_kelas_foreign FOREIGN KEY (id_kenaikan_kelas) REFERENCES public.kenaikan_kelas(id);
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT siswa_kenaikan_kelas_siswa_id_siswa_foreign FOREIGN KEY (id_siswa) REFERENCES public.siswa(id);
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT kelas_kenaikan_kelas_siswa_id_kelas_asal_foreign FOREIGN KEY (id_kelas_asal) REFERENCES public.kelas(id);
+
+ALTER TABLE ONLY public.kenaikan_kelas_siswa
+    ADD CONSTRAINT kelas_kenaikan_kelas_siswa_id_kelas_tujuan_foreign FOREIGN KEY (id_kelas_tujuan) REFERENCES public.kelas(id);
@@ -0,0 +1,5 @@
+DROP TRIGGER siswa_riwayat_kelas ON public.siswa;
+
+DROP FUNCTION public.riwayat_kelas_siswa();
+
+DROP TABLE public.riwayat_kelas CASCADE;
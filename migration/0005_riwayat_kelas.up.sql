@@ -0,0 +1,62 @@
+--- Riwayat Kelas
+--- Riwayat Kelas objek kelas siswa dari tanggal mulai sampai sebelum tanggal akhir. Akhir kosong
+--- selama siswa masih di kelas itu.
+CREATE TABLE public.riwayat_kelas (
+    id int GENERATED BY DEFAULT AS IDENTITY,
+    id_siswa int NOT NULL,
+    id_kelas int NOT NULL,
+    mulai date NOT NULL,
+    akhir date,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE public.riwayat_kelas OWNER TO school;
+
+ALTER TABLE ONLY public.riwayat_kelas
+    ADD CONSTRAINT riwayat_kelas_pkey PRIMARY KEY (id);
+
+--- riwayat belongs to siswa, delete of siswa deletes it
+ALTER TABLE ONLY public.riwayat_kelas
+    ADD CONSTRAINT siswa_riwayat_kelas_id_siswa_foreign FOREIGN KEY (id_siswa) REFERENCES public.siswa(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.riwayat_kelas
+    ADD CONSTRAINT kelas_riwayat_kelas_id_kelas_foreign FOREIGN KEY (id_kelas) REFERENCES public.kelas(id);
+
+CREATE UNIQUE INDEX riwayat_kelas_id_siswa_current_unique ON public.riwayat_kelas (id_siswa) WHERE akhir IS NULL AND deleted_at IS NULL;
+
+CREATE INDEX riwayat_kelas_id_kelas_mulai_index ON public.riwayat_kelas (id_kelas, mulai);
+
+
+--- riwayat_kelas_siswa ends riwayat of kelas that siswa leaves today and starts riwayat of kelas it
+--- moves to, whenever id_kelas of siswa is written. Every writer of siswa, CRUD, kenaikan kelas or
+--- SQL by hand, is recorded.
+CREATE FUNCTION public.riwayat_kelas_siswa() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.id_kelas = OLD.id_kelas THEN
+            RETURN NEW;
+        END IF;
+
+        UPDATE public.riwayat_kelas
+        SET akhir = current_date, updated_at = clock_timestamp()
+        WHERE id_siswa = NEW.id AND akhir IS NULL AND deleted_at IS NULL;
+    END IF;
+
+    INSERT INTO public.riwayat_kelas (id_siswa, id_kelas, mulai)
+    VALUES (NEW.id, NEW.id_kelas, current_date);
+
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER siswa_riwayat_kelas
+    AFTER INSERT OR UPDATE OF id_kelas ON public.siswa
+    FOR EACH ROW EXECUTE PROCEDURE public.riwayat_kelas_siswa();
+
+
+--- Backfill: siswa have been in their kelas since they were created
+INSERT INTO public.riwayat_kelas (id_siswa, id_kelas, mulai)
+SELECT id, id_kelas, coalesce(created_at, current_timestamp)::date
+FROM public.siswa;
@@ -0,0 +1,10 @@
+// Package migration embeds SQL migrations, so the binary can migrate database without the files.
+// Files are named {version}_{title}.up.sql and {version}_{title}.down.sql.
+package migration
+
+import "embed"
+
+// FS holds every migration file
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,146 @@
+package apierror
+
+// Code is a stable, machine-readable application error code.
+// Clients should switch on Code instead of parsing Message, which may change.
+// Codes are never removed or reused. Code that is not returned anymore stays reserved, marked
+// deprecated, so its value is never given to another error.
+type Code string
+
+// Generic error codes
+const (
+	InternalError     Code = "INTERNAL_ERROR"
+	DatabaseError     Code = "DATABASE_ERROR"
+	RequestBindFailed Code = "REQUEST_BIND_FAILED"
+	RequestInvalid    Code = "REQUEST_INVALID"
+	RequestTimeout    Code = "REQUEST_TIMEOUT"
+	RequestCanceled   Code = "REQUEST_CANCELED"
+
+	PreconditionFailed   Code = "PRECONDITION_FAILED"
+	PreconditionRequired Code = "PRECONDITION_REQUIRED"
+
+	// StillReferenced is returned by delete of row that other rows still reference
+	StillReferenced Code = "STILL_REFERENCED"
+)
+
+// Mata pelajaran error codes
+const (
+	MatpelIDRequired      Code = "MATPEL_ID_REQUIRED"
+	MatpelNamaRequired    Code = "MATPEL_NAMA_REQUIRED"
+	MatpelKodeRequired    Code = "MATPEL_KODE_REQUIRED"
+	MatpelTingkatRequired Code = "MATPEL_TINGKAT_REQUIRED"
+	MatpelKodeDuplicate   Code = "MATPEL_KODE_DUPLICATE"
+	MatpelNotFound        Code = "MATPEL_NOT_FOUND"
+)
+
+// Kelas error codes
+const (
+	KelasIDRequired          Code = "KELAS_ID_REQUIRED"
+	KelasNamaRequired        Code = "KELAS_NAMA_REQUIRED"
+	KelasTingkatRequired     Code = "KELAS_TINGKAT_REQUIRED"
+	KelasTahunAjaranRequired Code = "KELAS_TAHUN_AJARAN_REQUIRED"
+	KelasNamaDuplicate       Code = "KELAS_NAMA_DUPLICATE"
+	KelasNotFound            Code = "KELAS_NOT_FOUND"
+	KelasHasSiswa            Code = "KELAS_HAS_SISWA"
+)
+
+// Wali kelas error codes
+const (
+	WaliKelasIDRequired     Code = "WALI_KELAS_ID_REQUIRED"
+	WaliKelasNamaRequired   Code = "WALI_KELAS_NAMA_REQUIRED"
+	WaliKelasAlamatRequired Code = "WALI_KELAS_ALAMAT_REQUIRED"
+	WaliKelasTelponRequired Code = "WALI_KELAS_TELPON_REQUIRED"
+	WaliKelasNamaDuplicate  Code = "WALI_KELAS_NAMA_DUPLICATE"
+	WaliKelasNotFound       Code = "WALI_KELAS_NOT_FOUND"
+)
+
+// Siswa error codes
+const (
+	SiswaIDRequired      Code = "SISWA_ID_REQUIRED"
+	SiswaNamaRequired    Code = "SISWA_NAMA_REQUIRED"
+	SiswaKelasRequired   Code = "SISWA_KELAS_REQUIRED"
+	SiswaTingkatRequired Code = "SISWA_TINGKAT_REQUIRED"
+	SiswaAlamatRequired  Code = "SISWA_ALAMAT_REQUIRED"
+	SiswaNamaDuplicate   Code = "SISWA_NAMA_DUPLICATE"
+	SiswaKelasNotFound   Code = "SISWA_KELAS_NOT_FOUND"
+	SiswaNotFound        Code = "SISWA_NOT_FOUND"
+
+	SiswaKelasDeleted    Code = "SISWA_KELAS_DELETED"
+	SiswaTingkatMismatch Code = "SISWA_TINGKAT_MISMATCH"
+
+	// Deprecated: wali kelas of siswa is derived from kelas, see KelasWaliKelas codes. Not returned.
+	SiswaWaliKelasRequired Code = "SISWA_WALI_KELAS_REQUIRED"
+	// Deprecated: wali kelas of siswa is derived from kelas, see KelasWaliKelas codes. Not returned.
+	SiswaWaliKelasNotFound Code = "SISWA_WALI_KELAS_NOT_FOUND"
+	// Deprecated: wali kelas of siswa is derived from kelas, see KelasWaliKelas codes. Not returned.
+	SiswaWaliKelasDeleted Code = "SISWA_WALI_KELAS_DELETED"
+	// Deprecated: wali kelas of siswa is derived from kelas, see KelasWaliKelas codes. Not returned.
+	SiswaWaliKelasMismatch Code = "SISWA_WALI_KELAS_MISMATCH"
+)
+
+// Semester error codes
+const (
+	SemesterIDRequired          Code = "SEMESTER_ID_REQUIRED"
+	SemesterTahunAjaranRequired Code = "SEMESTER_TAHUN_AJARAN_REQUIRED"
+	SemesterSemesterRequired    Code = "SEMESTER_SEMESTER_REQUIRED"
+	SemesterMulaiRequired       Code = "SEMESTER_MULAI_REQUIRED"
+	SemesterAkhirRequired       Code = "SEMESTER_AKHIR_REQUIRED"
+	SemesterNotFound            Code = "SEMESTER_NOT_FOUND"
+
+	SemesterSemesterInvalid  Code = "SEMESTER_SEMESTER_INVALID"
+	SemesterDuplicate        Code = "SEMESTER_DUPLICATE"
+	SemesterAkhirBeforeMulai Code = "SEMESTER_AKHIR_BEFORE_MULAI"
+)
+
+// Kelas wali kelas error codes
+const (
+	KelasWaliKelasIDRequired          Code = "KELAS_WALI_KELAS_ID_REQUIRED"
+	KelasWaliKelasKelasRequired       Code = "KELAS_WALI_KELAS_KELAS_REQUIRED"
+	KelasWaliKelasWaliKelasRequired   Code = "KELAS_WALI_KELAS_WALI_KELAS_REQUIRED"
+	KelasWaliKelasTahunAjaranRequired Code = "KELAS_WALI_KELAS_TAHUN_AJARAN_REQUIRED"
+	KelasWaliKelasKelasNotFound       Code = "KELAS_WALI_KELAS_KELAS_NOT_FOUND"
+	KelasWaliKelasWaliKelasNotFound   Code = "KELAS_WALI_KELAS_WALI_KELAS_NOT_FOUND"
+	KelasWaliKelasNotFound            Code = "KELAS_WALI_KELAS_NOT_FOUND"
+
+	KelasWaliKelasKelasDeleted     Code = "KELAS_WALI_KELAS_KELAS_DELETED"
+	KelasWaliKelasWaliKelasDeleted Code = "KELAS_WALI_KELAS_WALI_KELAS_DELETED"
+	KelasWaliKelasDuplicate        Code = "KELAS_WALI_KELAS_DUPLICATE"
+
+	KelasWaliKelasTahunAjaranMismatch Code = "KELAS_WALI_KELAS_TAHUN_AJARAN_MISMATCH"
+)
+
+// User error codes
+const (
+	UserIDRequired       Code = "USER_ID_REQUIRED"
+	UserNamaRequired     Code = "USER_NAMA_REQUIRED"
+	UserAlamatRequired   Code = "USER_ALAMAT_REQUIRED"
+	UserPasswordRequired Code = "USER_PASSWORD_REQUIRED"
+	UserTeleponRequired  Code = "USER_TELEPON_REQUIRED"
+	UserNamaDuplicate    Code = "USER_NAMA_DUPLICATE"
+	UserNotFound         Code = "USER_NOT_FOUND"
+)
+
+// Kenaikan kelas error codes
+const (
+	KenaikanKelasIDRequired          Code = "KENAIKAN_KELAS_ID_REQUIRED"
+	KenaikanKelasNotFound            Code = "KENAIKAN_KELAS_NOT_FOUND"
+	KenaikanKelasKelasNotFound       Code = "KENAIKAN_KELAS_KELAS_NOT_FOUND"
+	KenaikanKelasSiswaNotFound       Code = "KENAIKAN_KELAS_SISWA_NOT_FOUND"
+	KenaikanKelasKelasTujuanNotFound Code = "KENAIKAN_KELAS_KELAS_TUJUAN_NOT_FOUND"
+	KenaikanKelasKelasTujuanRequired Code = "KENAIKAN_KELAS_KELAS_TUJUAN_REQUIRED"
+	KenaikanKelasKelasTujuanMismatch Code = "KENAIKAN_KELAS_KELAS_TUJUAN_MISMATCH"
+	KenaikanKelasHasilInvalid        Code = "KENAIKAN_KELAS_HASIL_INVALID"
+	KenaikanKelasDuplicate           Code = "KENAIKAN_KELAS_DUPLICATE"
+	KenaikanKelasUndone              Code = "KENAIKAN_KELAS_UNDONE"
+	KenaikanKelasUndoExpired         Code = "KENAIKAN_KELAS_UNDO_EXPIRED"
+	KenaikanKelasSiswaChanged        Code = "KENAIKAN_KELAS_SISWA_CHANGED"
+	KenaikanKelasKelasAsalNotFound   Code = "KENAIKAN_KELAS_KELAS_ASAL_NOT_FOUND"
+	KenaikanKelasKelasAsalDeleted    Code = "KENAIKAN_KELAS_KELAS_ASAL_DELETED"
+)
+
+// Riwayat kelas error codes
+const (
+	RiwayatKelasSiswaRequired Code = "RIWAYAT_KELAS_SISWA_REQUIRED"
+	RiwayatKelasKelasRequired Code = "RIWAYAT_KELAS_KELAS_REQUIRED"
+	RiwayatKelasSiswaNotFound Code = "RIWAYAT_KELAS_SISWA_NOT_FOUND"
+	RiwayatKelasKelasNotFound Code = "RIWAYAT_KELAS_KELAS_NOT_FOUND"
+)
@@ -1,10 +1,16 @@
 package apierror
 
+import "net/http"
+
+// StatusClientClosedRequest is status of request canceled by client before response, as nginx logs it.
+// Client never reads it, it is for logs and metrics.
+const StatusClientClosedRequest = 499
+
 // APIError ...
 // e, ok := err.(*apierror.APIError)
 type APIError struct {
 	HTTPStatus int    `json:"-"`
-	Code       int    `json:"code,omitempty"`
+	Code       Code   `json:"code,omitempty"`
 	Message    string `json:"error"`
 	Err        error  `json:"-"`
 }
@@ -14,7 +20,7 @@ func (e *APIError) Error() string {
 }
 
 // NewError ...
-func NewError(status int, code int, message string, err error) *APIError {
+func NewError(status int, code Code, message string, err error) *APIError {
 	return &APIError{
 		HTTPStatus: status,
 		Code:       code,
@@ -22,3 +28,12 @@ func NewError(status int, code int, message string, err error) *APIError {
 		Err:        err,
 	}
 }
+
+// StatusText returns text of HTTP status, including StatusClientClosedRequest
+func StatusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+
+	return http.StatusText(status)
+}
@@ -0,0 +1,302 @@
+package codegen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMigrations(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName        string
+		table               string
+		required            []string
+		expectedSelect      string
+		expectedInsert      string
+		expectedAssignments string
+		expectedRequired    []string
+		expectedTypes       []string
+		expectedUniques     []string
+		expectedForeigns    []string
+		expectedErr         bool
+	}{
+		{
+			scenarioName:        "unique column",
+			table:               "mata_pelajaran",
+			expectedSelect:      "id,nama,kode,tingkat,created_at,updated_at",
+			expectedInsert:      "nama, kode, tingkat",
+			expectedAssignments: "nama=$1,kode=$2,tingkat=$3,updated_at=DEFAULT",
+			expectedRequired:    []string{"Nama", "Kode", "Tingkat"},
+			expectedTypes:       []string{"string", "string", "int"},
+			expectedUniques:     []string{"mata_pelajaran_kode_unique"},
+			expectedForeigns:    []string{},
+		},
+		{
+			scenarioName:        "foreign keys and nullable column",
+			table:               "siswa",
+			expectedSelect:      "id,nama,id_kelas,tingkat,alamat,created_at,updated_at",
+			expectedInsert:      "nama, id_kelas, tingkat, alamat",
+			expectedAssignments: "nama=$1,id_kelas=$2,tingkat=$3,alamat=$4,updated_at=DEFAULT",
+			expectedRequired:    []string{"Nama", "IDKelas", "Tingkat"},
+			expectedTypes:       []string{"string", "int", "int", "*string"},
+			expectedUniques:     []string{},
+			expectedForeigns:    []string{"kelas_siswa_id_kelas_foreign"},
+		},
+		{
+			scenarioName:        "nullable column required by api",
+			table:               "siswa",
+			required:            []string{"alamat"},
+			expectedSelect:      "id,nama,id_kelas,tingkat,alamat,created_at,updated_at",
+			expectedInsert:      "nama, id_kelas, tingkat, alamat",
+			expectedAssignments: "nama=$1,id_kelas=$2,tingkat=$3,alamat=$4,updated_at=DEFAULT",
+			expectedRequired:    []string{"Nama", "IDKelas", "Tingkat", "Alamat"},
+			expectedTypes:       []string{"string", "int", "int", "string"},
+			expectedUniques:     []string{},
+			expectedForeigns:    []string{"kelas_siswa_id_kelas_foreign"},
+		},
+		{
+			scenarioName:        "table created by later migration",
+			table:               "kelas_wali_kelas",
+			expectedSelect:      "id,id_kelas,id_wali_kelas,tahun_ajaran,created_at,updated_at",
+			expectedInsert:      "id_kelas, id_wali_kelas, tahun_ajaran",
+			expectedAssignments: "id_kelas=$1,id_wali_kelas=$2,tahun_ajaran=$3,updated_at=DEFAULT",
+			expectedRequired:    []string{"IDKelas", "IDWaliKelas", "TahunAjaran"},
+			expectedTypes:       []string{"int", "int", "int"},
+			expectedUniques:     []string{},
+			expectedForeigns:    []string{"kelas_kelas_wali_kelas_id_kelas_foreign", "wali_kelas_kelas_wali_kelas_id_wali_kelas_foreign"},
+		},
+		{
+			scenarioName: "table not in migrations",
+			table:        "guru",
+			expectedErr:  true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			table, err := ParseMigrations("../../migration", v.table)
+			if v.expectedErr {
+				if err == nil {
+					t.Errorf("expect error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+			table.Required = v.required
+
+			err = table.Validate()
+			if err != nil {
+				t.Errorf("expect valid table, but got %s", err)
+				return
+			}
+
+			if table.SelectColumns() != v.expectedSelect {
+				t.Errorf("expect select %s, but got %s", v.expectedSelect, table.SelectColumns())
+				return
+			}
+
+			if table.InsertColumns() != v.expectedInsert {
+				t.Errorf("expect insert %s, but got %s", v.expectedInsert, table.InsertColumns())
+				return
+			}
+
+			if table.UpdateAssignments() != v.expectedAssignments {
+				t.Errorf("expect assignments %s, but got %s", v.expectedAssignments, table.UpdateAssignments())
+				return
+			}
+
+			required := []string{}
+			types := []string{}
+			for _, f := range table.Fields() {
+				if f.Required {
+					required = append(required, f.GoName)
+				}
+				types = append(types, f.GoType)
+			}
+			if !reflect.DeepEqual(required, v.expectedRequired) {
+				t.Errorf("expect required %v, but got %v", v.expectedRequired, required)
+				return
+			}
+			if !reflect.DeepEqual(types, v.expectedTypes) {
+				t.Errorf("expect types %v, but got %v", v.expectedTypes, types)
+				return
+			}
+
+			uniques := []string{}
+			for _, f := range table.UniqueFields() {
+				uniques = append(uniques, f.Constraint)
+			}
+			if !reflect.DeepEqual(uniques, v.expectedUniques) {
+				t.Errorf("expect uniques %v, but got %v", v.expectedUniques, uniques)
+				return
+			}
+
+			foreigns := []string{}
+			for _, f := range table.ForeignFields() {
+				foreigns = append(foreigns, f.Constraint)
+			}
+			if !reflect.DeepEqual(foreigns, v.expectedForeigns) {
+				t.Errorf("expect foreign keys %v, but got %v", v.expectedForeigns, foreigns)
+				return
+			}
+		})
+	}
+}
+
+func TestParseSQL_Alter(t *testing.T) {
+	sql := `
+		CREATE TYPE public.status_type AS ENUM ('aktif', 'lulus');
+
+		CREATE TABLE public.guru (
+			id int GENERATED BY DEFAULT AS IDENTITY,
+			nama text NOT NULL,
+			nip varchar(32) NOT NULL UNIQUE,
+			telpon text,
+			created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+			updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+			CONSTRAINT guru_nama_unique UNIQUE (nama)
+		);
+
+		-- later migrations
+		ALTER TABLE public.guru ADD COLUMN status status_type NOT NULL DEFAULT 'aktif';
+		ALTER TABLE public.guru ADD COLUMN gaji numeric(12,2);
+		ALTER TABLE public.guru DROP COLUMN telpon;
+		ALTER TABLE ONLY public.guru ADD CONSTRAINT guru_pkey PRIMARY KEY (id);
+		ALTER TABLE public.guru DROP CONSTRAINT guru_nama_unique;
+		ALTER TABLE public.guru ALTER COLUMN gaji SET NOT NULL;
+	`
+
+	table, err := ParseSQL(sql, "guru")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	err = table.Validate()
+	if err != nil {
+		t.Errorf("expect valid table, but got %s", err)
+		return
+	}
+
+	testScenarios := []struct {
+		scenarioName     string
+		goName           string
+		expectedType     string
+		expectedRequired bool
+	}{
+		{scenarioName: "varchar with length", goName: "NIP", expectedType: "string", expectedRequired: true},
+		{scenarioName: "enum with default", goName: "Status", expectedType: "string", expectedRequired: false},
+		{scenarioName: "numeric set not null", goName: "Gaji", expectedType: "float64", expectedRequired: true},
+	}
+
+	fields := map[string]Field{}
+	for _, f := range table.Fields() {
+		fields[f.GoName] = f
+	}
+
+	if _, ok := fields["Telpon"]; ok {
+		t.Errorf("expect dropped column telpon to be removed")
+		return
+	}
+
+	if !reflect.DeepEqual(fields["Status"].Enum, []string{"aktif", "lulus"}) {
+		t.Errorf("expect enum values [aktif lulus], but got %v", fields["Status"].Enum)
+		return
+	}
+
+	uniques := table.UniqueFields()
+	if len(uniques) != 1 || uniques[0].Constraint != "guru_nip_key" {
+		t.Errorf("expect only inline unique guru_nip_key, but got %v", uniques)
+		return
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			f, ok := fields[v.goName]
+			if !ok {
+				t.Errorf("expect field %s", v.goName)
+				return
+			}
+
+			if f.GoType != v.expectedType {
+				t.Errorf("expect type %s, but got %s", v.expectedType, f.GoType)
+				return
+			}
+
+			if f.Required != v.expectedRequired {
+				t.Errorf("expect required %t, but got %t", v.expectedRequired, f.Required)
+				return
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	testScenarios := []struct {
+		column   string
+		expected string
+	}{
+		{column: "nama", expected: "Nama"},
+		{column: "id_kelas", expected: "IDKelas"},
+		{column: "id_wali_kelas", expected: "IDWaliKelas"},
+		{column: "foto_url", expected: "FotoURL"},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.column, func(t *testing.T) {
+			if GoName(v.column) != v.expected {
+				t.Errorf("expect %s, but got %s", v.expected, GoName(v.column))
+			}
+		})
+	}
+}
+
+func TestTable_Derived(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		derived      Column
+		expectedType string
+		expectedErr  bool
+	}{
+		{scenarioName: "derived int", derived: Column{Name: "id_wali_kelas", DataType: "int", Nullable: true}, expectedType: "*int"},
+		{scenarioName: "derived column of table", derived: Column{Name: "tingkat", DataType: "int", Nullable: true}, expectedErr: true},
+		{scenarioName: "derived unsupported type", derived: Column{Name: "lokasi", DataType: "point", Nullable: true}, expectedErr: true},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			table, err := ParseMigrations("../../migration", "siswa")
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+			table.Derived = []Column{v.derived}
+
+			err = table.Validate()
+			if v.expectedErr {
+				if err == nil {
+					t.Errorf("expect error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect valid table, but got %s", err)
+				return
+			}
+
+			derived := table.DerivedFields()
+			if len(derived) != 1 || derived[0].GoType != v.expectedType {
+				t.Errorf("expect derived field of type %s, but got %v", v.expectedType, derived)
+				return
+			}
+			for _, f := range table.Fields() {
+				if f.Name == v.derived.Name {
+					t.Errorf("expect derived field %s not to be writable", f.Name)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package codegen
+
+import (
+	"bytes"
+	"context"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var generatedRegex = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// Generator renders models of a manifest. Manifest paths are resolved against Root.
+type Generator struct {
+	Root     string
+	Manifest *Manifest
+
+	// TemplateDir overrides manifest templates directory. Relative to Root unless absolute
+	TemplateDir string
+
+	// DB, when set, is used to read tables from information_schema instead of migrations
+	DB       *sqlx.DB
+	DBSchema string
+}
+
+// File is a rendered output file
+type File struct {
+	// Path is relative to Root
+	Path    string
+	Content []byte
+
+	// Once is true for hand-written extension files that are only created when missing
+	Once bool
+}
+
+// output is a template rendered to path. Extension files are rendered only once
+type output struct {
+	template string
+	path     string
+	once     bool
+}
+
+// Render renders every file of model
+func (g *Generator) Render(ctx context.Context, m Model) ([]File, error) {
+	table, err := g.table(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Model          string
+		ModelLowerCase string
+		CodePrefix     string
+		Table          *Table
+	}{
+		Model:          m.Model,
+		ModelLowerCase: m.ModelLowerCase,
+		CodePrefix:     m.CodePrefix,
+		Table:          table,
+	}
+
+	outputs := []output{
+		{template: "schema.tmpl", path: filepath.Join(g.Manifest.SchemaDir, m.ModelLowerCase+"_gen.go")},
+		{template: "service.tmpl", path: filepath.Join(g.Manifest.ServiceDir, m.ModelLowerCase+"_gen.go")},
+		{template: "service_ext.tmpl", path: filepath.Join(g.Manifest.ServiceDir, m.ModelLowerCase+".go"), once: true},
+		{template: "test.tmpl", path: filepath.Join(g.Manifest.ServiceDir, m.ModelLowerCase+"_gen_test.go")},
+		{template: "controller.tmpl", path: filepath.Join(g.Manifest.ControllerDir, m.ModelLowerCase+"_gen.go")},
+		{template: "controller_ext.tmpl", path: filepath.Join(g.Manifest.ControllerDir, m.ModelLowerCase+".go"), once: true},
+		{template: "memory.tmpl", path: filepath.Join(g.Manifest.MemoryDir, m.ModelLowerCase+"_gen.go")},
+	}
+
+	files := []File{}
+	for _, o := range outputs {
+		t, err := g.template(o.template)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		err = t.Execute(&buf, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "render: execute "+o.template+" for "+m.Model+" failed")
+		}
+
+		content, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "render: format "+o.path+" failed")
+		}
+
+		files = append(files, File{Path: o.path, Content: content, Once: o.once})
+	}
+
+	return files, nil
+}
+
+// Write writes files under Root. Extension files that already exist are skipped.
+// It refuses to overwrite a file that is not generated, so hand-written code is never lost.
+func (g *Generator) Write(files []File) ([]string, error) {
+	written := []string{}
+	for _, f := range files {
+		path := filepath.Join(g.Root, f.Path)
+
+		existing, err := ioutil.ReadFile(path)
+		if err == nil {
+			if f.Once {
+				continue
+			}
+			if !generatedRegex.Match(existing) {
+				return written, errors.New("write: " + f.Path + " exists and is not generated. Move hand-written code to extension file and remove it")
+			}
+			if bytes.Equal(existing, f.Content) {
+				continue
+			}
+		} else if !os.IsNotExist(err) {
+			return written, errors.Wrap(err, "write: read "+f.Path+" failed")
+		}
+
+		err = ioutil.WriteFile(path, f.Content, 0644)
+		if err != nil {
+			return written, errors.Wrap(err, "write: write "+f.Path+" failed")
+		}
+		written = append(written, f.Path)
+	}
+
+	return written, nil
+}
+
+// Diff returns unified diff between files on disk and rendered files, as Write would apply them
+func (g *Generator) Diff(files []File) (string, error) {
+	var out bytes.Buffer
+	for _, f := range files {
+		existing, err := ioutil.ReadFile(filepath.Join(g.Root, f.Path))
+		if err != nil && !os.IsNotExist(err) {
+			return "", errors.Wrap(err, "diff: read "+f.Path+" failed")
+		}
+		if err == nil && f.Once {
+			continue
+		}
+
+		from := "a/" + f.Path
+		if os.IsNotExist(err) {
+			from = "/dev/null"
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(string(f.Content)),
+			FromFile: from,
+			ToFile:   "b/" + f.Path,
+			Context:  3,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "diff: diff "+f.Path+" failed")
+		}
+		out.WriteString(diff)
+	}
+
+	return out.String(), nil
+}
+
+func (g *Generator) table(ctx context.Context, m Model) (*Table, error) {
+	var table *Table
+	var err error
+	if g.DB != nil {
+		table, err = Introspect(ctx, g.DB, g.DBSchema, m.Table)
+	} else {
+		table, err = ParseMigrations(g.path(g.Manifest.Migrations), m.Table)
+	}
+	if err != nil {
+		return nil, err
+	}
+	table.Required = m.Required
+	table.Scope = m.Scope
+	for _, d := range m.Derived {
+		table.Derived = append(table.Derived, Column{Name: d.Column, DataType: d.Type, Nullable: true})
+	}
+
+	err = table.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// generated tests create referenced rows with fixtures of other models
+	table.Models = map[string]string{}
+	for _, v := range g.Manifest.Models {
+		table.Models[v.Table] = v.Model
+	}
+	for _, f := range table.ForeignFields() {
+		if _, ok := table.Models[f.RefTable]; !ok {
+			return nil, errors.New("codegen: " + m.Model + " references table " + f.RefTable + " that is not in manifest")
+		}
+	}
+
+	return table, nil
+}
+
+func (g *Generator) template(name string) (*template.Template, error) {
+	dir := g.Manifest.Templates
+	if g.TemplateDir != "" {
+		dir = g.TemplateDir
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(g.path(dir), name))
+	if err != nil {
+		return nil, errors.Wrap(err, "template: read "+name+" failed")
+	}
+
+	t, err := template.New(name).Parse(string(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "template: parse "+name+" failed")
+	}
+
+	return t, nil
+}
+
+func (g *Generator) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+
+	return filepath.Join(g.Root, p)
+}
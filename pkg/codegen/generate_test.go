@@ -0,0 +1,249 @@
+package codegen
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codegen")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	jsonManifest := filepath.Join(dir, "models.json")
+	ioutil.WriteFile(jsonManifest, []byte(`{"models": [{"model": "Guru_Mapel", "required": ["alamat"]}]}`), 0644)
+
+	unknownField := filepath.Join(dir, "unknown.yaml")
+	ioutil.WriteFile(unknownField, []byte("models:\n  - model: Guru\n    tabel: guru\n"), 0644)
+
+	testScenarios := []struct {
+		scenarioName       string
+		path               string
+		model              string
+		expectedLowerCase  string
+		expectedCodePrefix string
+		expectedTable      string
+		expectedErr        bool
+	}{
+		{
+			scenarioName:       "repository manifest",
+			path:               "../../" + DefaultManifest,
+			model:              "mata_pelajaran",
+			expectedLowerCase:  "mata_pelajaran",
+			expectedCodePrefix: "Matpel",
+			expectedTable:      "mata_pelajaran",
+		},
+		{
+			scenarioName:       "json manifest with defaults",
+			path:               jsonManifest,
+			model:              "Guru_Mapel",
+			expectedLowerCase:  "guru_mapel",
+			expectedCodePrefix: "GuruMapel",
+			expectedTable:      "guru_mapel",
+		},
+		{
+			scenarioName: "unknown field is rejected",
+			path:         unknownField,
+			expectedErr:  true,
+		},
+		{
+			scenarioName: "unsupported extension",
+			path:         "../../Makefile",
+			expectedErr:  true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			manifest, err := LoadManifest(v.path)
+			if v.expectedErr {
+				if err == nil {
+					t.Errorf("expect error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			m, err := manifest.Find(v.model)
+			if err != nil {
+				t.Errorf("expect model %s, but got %s", v.model, err)
+				return
+			}
+
+			if m.ModelLowerCase != v.expectedLowerCase || m.CodePrefix != v.expectedCodePrefix || m.Table != v.expectedTable {
+				t.Errorf("expect %s %s %s, but got %s %s %s", v.expectedLowerCase, v.expectedCodePrefix, v.expectedTable, m.ModelLowerCase, m.CodePrefix, m.Table)
+				return
+			}
+		})
+	}
+}
+
+// Generated files must be in sync with templates, manifest and migrations.
+// If this fails, run go run ./cmd/gen and commit the result.
+func TestGenerator_UpToDate(t *testing.T) {
+	root, err := FindRoot(".")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	manifest, err := LoadManifest(filepath.Join(root, DefaultManifest))
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	g := &Generator{Root: root, Manifest: manifest}
+	for _, m := range manifest.Models {
+		t.Run(m.Model, func(t *testing.T) {
+			files, err := g.Render(context.Background(), m)
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			diff, err := g.Diff(files)
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			if diff != "" {
+				t.Errorf("expect generated files up to date, but got diff:\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestGenerator_ForeignNotInManifest(t *testing.T) {
+	root, err := FindRoot(".")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	manifest, err := LoadManifest(filepath.Join(root, DefaultManifest))
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	// siswa references kelas, which is no longer listed
+	siswa, _ := manifest.Find("siswa")
+	manifest.Models = []Model{*siswa}
+
+	g := &Generator{Root: root, Manifest: manifest}
+	_, err = g.Render(context.Background(), *siswa)
+	if err == nil {
+		t.Errorf("expect error, but got none")
+		return
+	}
+}
+
+func TestGenerator_Write(t *testing.T) {
+	repoRoot, err := FindRoot(".")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	root, err := ioutil.TempDir("", "codegen")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+	defer os.RemoveAll(root)
+
+	manifest := &Manifest{
+		Templates:     filepath.Join(repoRoot, "template"),
+		Migrations:    filepath.Join(repoRoot, "migration"),
+		SchemaDir:     ".",
+		ServiceDir:    ".",
+		ControllerDir: "controller",
+		MemoryDir:     "memory",
+	}
+	os.Mkdir(filepath.Join(root, "controller"), 0755)
+	os.Mkdir(filepath.Join(root, "memory"), 0755)
+
+	g := &Generator{Root: root, Manifest: manifest}
+	files, err := g.Render(context.Background(), Model{Model: "Kelas", ModelLowerCase: "kelas", CodePrefix: "Kelas", Table: "kelas"})
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	// schema and service share a directory here, so give schema its own name
+	files[0].Path = "schema_gen.go"
+
+	testScenarios := []struct {
+		scenarioName    string
+		prepare         func()
+		expectedWritten int
+		expectedErr     bool
+	}{
+		{
+			scenarioName:    "first run writes every file",
+			expectedWritten: 7,
+		},
+		{
+			scenarioName:    "second run writes nothing",
+			expectedWritten: 0,
+		},
+		{
+			scenarioName: "changed generated file is overwritten, extension file is kept",
+			prepare: func() {
+				ioutil.WriteFile(filepath.Join(root, "kelas_gen.go"), []byte("// Code generated by hand. DO NOT EDIT.\n"), 0644)
+				ioutil.WriteFile(filepath.Join(root, "kelas.go"), []byte("package service\n"), 0644)
+			},
+			expectedWritten: 1,
+		},
+		{
+			scenarioName: "hand-written file at generated path is not overwritten",
+			prepare: func() {
+				ioutil.WriteFile(filepath.Join(root, "kelas_gen.go"), []byte("package service\n"), 0644)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			if v.prepare != nil {
+				v.prepare()
+			}
+
+			written, err := g.Write(files)
+			if v.expectedErr {
+				if err == nil {
+					t.Errorf("expect error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			if len(written) != v.expectedWritten {
+				t.Errorf("expect %d written files, but got %v", v.expectedWritten, written)
+				return
+			}
+		})
+	}
+
+	ext, _ := ioutil.ReadFile(filepath.Join(root, "kelas.go"))
+	if strings.TrimSpace(string(ext)) != "package service" {
+		t.Errorf("expect extension file to be kept, but got %s", ext)
+	}
+}
@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Introspect reads table columns and constraints from information_schema of a live database
+func Introspect(ctx context.Context, db *sqlx.DB, schemaName string, table string) (*Table, error) {
+	t := &Table{Name: table, Enums: map[string][]string{}}
+
+	{
+		columns := []struct {
+			Name       string  `db:"column_name"`
+			DataType   string  `db:"data_type"`
+			UDTName    string  `db:"udt_name"`
+			IsNullable string  `db:"is_nullable"`
+			Default    *string `db:"column_default"`
+			IsIdentity string  `db:"is_identity"`
+		}{}
+
+		err := db.SelectContext(ctx, &columns, `
+			SELECT column_name, data_type, udt_name, is_nullable, column_default, is_identity
+			FROM information_schema.columns
+			WHERE table_schema=$1 AND table_name=$2
+			ORDER BY ordinal_position;`,
+			schemaName, table)
+		if err != nil {
+			return nil, errors.Wrap(err, "introspect: get columns failed")
+		}
+
+		if len(columns) == 0 {
+			return nil, errors.New("introspect: table " + schemaName + "." + table + " is not exists")
+		}
+
+		for _, c := range columns {
+			if c.DataType == "USER-DEFINED" {
+				values := []string{}
+				err := db.SelectContext(ctx, &values, `
+					SELECT e.enumlabel
+					FROM pg_type t JOIN pg_enum e ON e.enumtypid=t.oid
+					WHERE t.typname=$1
+					ORDER BY e.enumsortorder;`,
+					c.UDTName)
+				if err != nil {
+					return nil, errors.Wrap(err, "introspect: get enum values failed")
+				}
+
+				t.Enums[strings.ToLower(c.UDTName)] = values
+				c.DataType = c.UDTName
+			}
+
+			t.Columns = append(t.Columns, Column{
+				Name:       c.Name,
+				DataType:   c.DataType,
+				Nullable:   c.IsNullable == "YES",
+				HasDefault: c.Default != nil,
+				Identity:   c.IsIdentity == "YES" || (c.Default != nil && strings.HasPrefix(*c.Default, "nextval(")),
+			})
+		}
+	}
+
+	{
+		constraints := []struct {
+			Name     string  `db:"constraint_name"`
+			Type     string  `db:"constraint_type"`
+			Column   string  `db:"column_name"`
+			RefTable *string `db:"ref_table"`
+		}{}
+
+		err := db.SelectContext(ctx, &constraints, `
+			SELECT tc.constraint_name, tc.constraint_type, kcu.column_name,
+				(SELECT ccu.table_name FROM information_schema.constraint_column_usage ccu
+				 WHERE ccu.constraint_schema=tc.constraint_schema AND ccu.constraint_name=tc.constraint_name
+				 LIMIT 1) AS ref_table
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON kcu.constraint_schema=tc.constraint_schema AND kcu.constraint_name=tc.constraint_name
+			WHERE tc.table_schema=$1 AND tc.table_name=$2 AND tc.constraint_type IN ('UNIQUE', 'FOREIGN KEY')
+			ORDER BY tc.constraint_name, kcu.ordinal_position;`,
+			schemaName, table)
+		if err != nil {
+			return nil, errors.Wrap(err, "introspect: get constraints failed")
+		}
+
+		for _, c := range constraints {
+			if c.Type == "UNIQUE" {
+				t.Uniques = appendConstraintColumn(t.Uniques, c.Name, c.Column, "")
+				continue
+			}
+
+			refTable := ""
+			if c.RefTable != nil {
+				refTable = *c.RefTable
+			}
+			t.ForeignKeys = appendConstraintColumn(t.ForeignKeys, c.Name, c.Column, refTable)
+		}
+	}
+
+	return t, nil
+}
+
+func appendConstraintColumn(constraints []Constraint, name string, column string, refTable string) []Constraint {
+	for i := range constraints {
+		if constraints[i].Name == name {
+			constraints[i].Columns = append(constraints[i].Columns, column)
+			return constraints
+		}
+	}
+
+	return append(constraints, Constraint{Name: name, Columns: []string{column}, RefTable: refTable})
+}
@@ -0,0 +1,152 @@
+package codegen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultManifest is manifest path relative to repository root
+const DefaultManifest = "template/models.yaml"
+
+// Manifest lists models to generate. Paths are relative to repository root.
+type Manifest struct {
+	Templates     string  `json:"templates" yaml:"templates"`
+	Migrations    string  `json:"migrations" yaml:"migrations"`
+	SchemaDir     string  `json:"schemaDir" yaml:"schemaDir"`
+	ServiceDir    string  `json:"serviceDir" yaml:"serviceDir"`
+	ControllerDir string  `json:"controllerDir" yaml:"controllerDir"`
+	MemoryDir     string  `json:"memoryDir" yaml:"memoryDir"`
+	Models        []Model `json:"models" yaml:"models"`
+}
+
+// Model is one generated entity
+type Model struct {
+	// Model is Go type prefix, e.g. Wali_Kelas
+	Model string `json:"model" yaml:"model"`
+
+	// ModelLowerCase names files and routes. Default is lower case Model
+	ModelLowerCase string `json:"modelLowerCase" yaml:"modelLowerCase"`
+
+	// CodePrefix prefixes error codes in pkg/apierror, e.g. WaliKelas
+	CodePrefix string `json:"codePrefix" yaml:"codePrefix"`
+
+	// Table is database table. Default is ModelLowerCase
+	Table string `json:"table" yaml:"table"`
+
+	// Required lists nullable columns that API still requires on create
+	Required []string `json:"required" yaml:"required"`
+
+	// Derived lists read-only response fields that are not columns of table
+	Derived []Derived `json:"derived" yaml:"derived"`
+
+	// Scope is int field holding tahun ajaran, e.g. tahun_ajaran. Grid of model lists rows of
+	// active semester, or of semester chosen by semester parameter. Default is not scoped
+	Scope string `json:"scope" yaml:"scope"`
+}
+
+// Derived is read-only response field that is derived from other tables, e.g. wali kelas of siswa
+// from assignment of its kelas. Service selects it with {modelLowerCase}Derived of its hand-written
+// file, memory store sets it with hand-written derive{Model}. It is null when there is nothing to
+// derive it from.
+type Derived struct {
+	// Column names field in JSON and SELECT
+	Column string `json:"column" yaml:"column"`
+
+	// Type is SQL data type of field
+	Type string `json:"type" yaml:"type"`
+}
+
+// LoadManifest reads YAML or JSON manifest, chosen by file extension, and fills defaults
+func LoadManifest(path string) (*Manifest, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadmanifest: read manifest failed")
+	}
+
+	m := &Manifest{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, m)
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(content, m)
+	default:
+		return nil, errors.New("loadmanifest: manifest must be .yaml, .yml or .json")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loadmanifest: parse manifest failed")
+	}
+
+	if m.Templates == "" {
+		m.Templates = "template"
+	}
+	if m.Migrations == "" {
+		m.Migrations = "migration"
+	}
+	if m.SchemaDir == "" {
+		m.SchemaDir = "api/schema"
+	}
+	if m.ServiceDir == "" {
+		m.ServiceDir = "service"
+	}
+	if m.ControllerDir == "" {
+		m.ControllerDir = "api/controller"
+	}
+	if m.MemoryDir == "" {
+		m.MemoryDir = "service/memory"
+	}
+
+	for i := range m.Models {
+		v := &m.Models[i]
+		if v.Model == "" {
+			return nil, errors.New("loadmanifest: model name is not set")
+		}
+		if v.ModelLowerCase == "" {
+			v.ModelLowerCase = strings.ToLower(v.Model)
+		}
+		if v.CodePrefix == "" {
+			v.CodePrefix = strings.Replace(v.Model, "_", "", -1)
+		}
+		if v.Table == "" {
+			v.Table = v.ModelLowerCase
+		}
+	}
+
+	return m, nil
+}
+
+// Find returns model by name, either Model or ModelLowerCase
+func (m *Manifest) Find(name string) (*Model, error) {
+	for i := range m.Models {
+		if m.Models[i].Model == name || m.Models[i].ModelLowerCase == name {
+			return &m.Models[i], nil
+		}
+	}
+
+	return nil, errors.New("find: model " + name + " is not in manifest")
+}
+
+// FindRoot walks up from dir until it finds repository root, identified by manifest
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "findroot: resolve directory failed")
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, DefaultManifest)); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("findroot: " + DefaultManifest + " is not found in any parent directory")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,284 @@
+package codegen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	lineCommentRegex  = regexp.MustCompile(`--[^\n]*`)
+	createEnumRegex   = regexp.MustCompile(`(?is)CREATE\s+TYPE\s+(?:public\.)?"?(\w+)"?\s+AS\s+ENUM\s*\((.*)\)`)
+	enumValueRegex    = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	createTableRegex  = regexp.MustCompile(`(?is)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:public\.)?"?(\w+)"?\s*\((.*)\)$`)
+	dropTableRegex    = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:public\.)?"?(\w+)"?`)
+	alterTableRegex   = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?:public\.)?"?(\w+)"?\s+(.*)$`)
+	addConstraintRgx  = regexp.MustCompile(`(?is)^ADD\s+((?:CONSTRAINT|PRIMARY|UNIQUE|FOREIGN|CHECK|EXCLUDE)\b.*)$`)
+	dropConstraintRgx = regexp.MustCompile(`(?is)^DROP\s+CONSTRAINT\s+(?:IF\s+EXISTS\s+)?"?(\w+)"?`)
+	addColumnRegex    = regexp.MustCompile(`(?is)^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(.*)$`)
+	dropColumnRegex   = regexp.MustCompile(`(?is)^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?"?(\w+)"?`)
+	renameColumnRegex = regexp.MustCompile(`(?is)^RENAME\s+(?:COLUMN\s+)?"?(\w+)"?\s+TO\s+"?(\w+)"?`)
+	alterColumnRegex  = regexp.MustCompile(`(?is)^ALTER\s+(?:COLUMN\s+)?"?(\w+)"?\s+(SET\s+NOT\s+NULL|DROP\s+NOT\s+NULL|SET\s+DEFAULT|DROP\s+DEFAULT|(?:SET\s+DATA\s+)?TYPE\s+(.*))`)
+	constraintNameRgx = regexp.MustCompile(`(?i)^CONSTRAINT\s+"?(\w+)"?\s+`)
+	uniqueRegex       = regexp.MustCompile(`(?is)^UNIQUE\s*\(([^)]*)\)`)
+	foreignKeyRegex   = regexp.MustCompile(`(?is)^FOREIGN\s+KEY\s*\(([^)]*)\)\s+REFERENCES\s+(?:public\.)?"?(\w+)"?`)
+	referencesRegex   = regexp.MustCompile(`(?i)REFERENCES\s+(?:public\.)?"?(\w+)"?`)
+	usingRegex        = regexp.MustCompile(`(?is)\s+USING\s.*$`)
+	constraintKeyword = regexp.MustCompile(`(?i)^(CONSTRAINT|PRIMARY|UNIQUE|FOREIGN|CHECK|EXCLUDE)\b`)
+	columnKeyword     = regexp.MustCompile(`(?i)\s(NOT\s+NULL|NULL|DEFAULT|GENERATED|PRIMARY\s+KEY|UNIQUE|REFERENCES|CHECK|CONSTRAINT|COLLATE)\b`)
+)
+
+// ParseMigrations reads every *.up.sql file in dir in name order and returns table as
+// it looks after all of them are applied
+func ParseMigrations(dir string, table string) (*Table, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsemigrations: list migration files failed")
+	}
+	sort.Strings(files)
+
+	sqls := []string{}
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsemigrations: read "+f+" failed")
+		}
+		sqls = append(sqls, string(content))
+	}
+
+	t, err := ParseSQL(strings.Join(sqls, ";\n"), table)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsemigrations")
+	}
+
+	return t, nil
+}
+
+// ParseSQL applies CREATE TABLE and ALTER TABLE statements in sql and returns table
+func ParseSQL(sql string, table string) (*Table, error) {
+	t := &Table{Name: table, Enums: map[string][]string{}}
+	created := false
+
+	sql = lineCommentRegex.ReplaceAllString(sql, "")
+	for _, stmt := range splitTopLevel(sql, ';') {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+
+		if m := createEnumRegex.FindStringSubmatch(stmt); m != nil {
+			values := []string{}
+			for _, v := range enumValueRegex.FindAllStringSubmatch(m[2], -1) {
+				values = append(values, strings.Replace(v[1], "''", "'", -1))
+			}
+			t.Enums[strings.ToLower(m[1])] = values
+			continue
+		}
+
+		if m := createTableRegex.FindStringSubmatch(stmt); m != nil {
+			if m[1] != table {
+				continue
+			}
+			t.Columns, t.Uniques, t.ForeignKeys = nil, nil, nil
+			created = true
+
+			for _, def := range splitTopLevel(m[2], ',') {
+				def = strings.TrimSpace(def)
+				if def == "" {
+					continue
+				}
+				if constraintKeyword.MatchString(def) {
+					t.addConstraint(def)
+					continue
+				}
+				t.addColumn(def)
+			}
+			continue
+		}
+
+		if m := dropTableRegex.FindStringSubmatch(stmt); m != nil && m[1] == table {
+			t.Columns, t.Uniques, t.ForeignKeys = nil, nil, nil
+			created = false
+			continue
+		}
+
+		if m := alterTableRegex.FindStringSubmatch(stmt); m != nil && m[1] == table {
+			for _, action := range splitTopLevel(m[2], ',') {
+				t.alter(strings.TrimSpace(action))
+			}
+		}
+	}
+
+	if !created {
+		return nil, errors.New("parsesql: table " + table + " is not created by migrations")
+	}
+
+	return t, nil
+}
+
+func (t *Table) alter(action string) {
+	if m := addConstraintRgx.FindStringSubmatch(action); m != nil {
+		t.addConstraint(m[1])
+		return
+	}
+
+	if m := dropConstraintRgx.FindStringSubmatch(action); m != nil {
+		t.Uniques = removeConstraint(t.Uniques, m[1])
+		t.ForeignKeys = removeConstraint(t.ForeignKeys, m[1])
+		return
+	}
+
+	if m := renameColumnRegex.FindStringSubmatch(action); m != nil {
+		if c := t.column(m[1]); c != nil {
+			c.Name = m[2]
+		}
+		return
+	}
+
+	if m := alterColumnRegex.FindStringSubmatch(action); m != nil {
+		c := t.column(m[1])
+		if c == nil {
+			return
+		}
+		switch op := strings.ToUpper(strings.Join(strings.Fields(m[2]), " ")); {
+		case op == "SET NOT NULL":
+			c.Nullable = false
+		case op == "DROP NOT NULL":
+			c.Nullable = true
+		case op == "SET DEFAULT":
+			c.HasDefault = true
+		case op == "DROP DEFAULT":
+			c.HasDefault = false
+		default:
+			c.DataType = strings.TrimSpace(usingRegex.ReplaceAllString(m[3], ""))
+		}
+		return
+	}
+
+	if m := dropColumnRegex.FindStringSubmatch(action); m != nil {
+		for i, c := range t.Columns {
+			if c.Name == m[1] {
+				t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+
+	if m := addColumnRegex.FindStringSubmatch(action); m != nil {
+		t.addColumn(m[1])
+	}
+}
+
+// addConstraint parses unique and foreign key table constraint, either inline in CREATE TABLE
+// or from ALTER TABLE ADD. Other constraints are ignored.
+func (t *Table) addConstraint(def string) {
+	name := ""
+	if m := constraintNameRgx.FindStringSubmatch(def); m != nil {
+		name = m[1]
+		def = def[len(m[0]):]
+	}
+
+	if m := uniqueRegex.FindStringSubmatch(def); m != nil {
+		columns := splitColumns(m[1])
+		if name == "" {
+			name = t.Name + "_" + strings.Join(columns, "_") + "_key"
+		}
+		t.Uniques = append(t.Uniques, Constraint{Name: name, Columns: columns})
+		return
+	}
+
+	if m := foreignKeyRegex.FindStringSubmatch(def); m != nil {
+		columns := splitColumns(m[1])
+		if name == "" {
+			name = t.Name + "_" + strings.Join(columns, "_") + "_fkey"
+		}
+		t.ForeignKeys = append(t.ForeignKeys, Constraint{Name: name, Columns: columns, RefTable: m[2]})
+	}
+}
+
+// addColumn parses column definition such as: nama text NOT NULL
+func (t *Table) addColumn(def string) {
+	fields := strings.Fields(def)
+	if len(fields) < 2 {
+		return
+	}
+
+	name := strings.Trim(fields[0], `"`)
+	rest := " " + strings.Join(fields[1:], " ")
+
+	dataType := rest
+	if loc := columnKeyword.FindStringIndex(rest); loc != nil {
+		dataType = rest[:loc[0]]
+	}
+
+	upper := strings.ToUpper(rest)
+	c := Column{
+		Name:       name,
+		DataType:   strings.TrimSpace(dataType),
+		Nullable:   !strings.Contains(upper, "NOT NULL") && !strings.Contains(upper, "PRIMARY KEY"),
+		HasDefault: strings.Contains(upper, " DEFAULT "),
+		Identity:   strings.Contains(upper, "GENERATED") && strings.Contains(upper, "IDENTITY"),
+	}
+	if strings.HasSuffix(strings.ToLower(c.DataType), "serial") {
+		c.Identity = true
+	}
+
+	t.Columns = append(t.Columns, c)
+
+	if strings.Contains(upper, " UNIQUE") {
+		t.Uniques = append(t.Uniques, Constraint{Name: t.Name + "_" + name + "_key", Columns: []string{name}})
+	}
+
+	if m := referencesRegex.FindStringSubmatch(rest); m != nil {
+		t.ForeignKeys = append(t.ForeignKeys, Constraint{Name: t.Name + "_" + name + "_fkey", Columns: []string{name}, RefTable: m[1]})
+	}
+}
+
+// splitTopLevel splits s by sep, ignoring separators inside parentheses and quotes
+func splitTopLevel(s string, sep rune) []string {
+	parts := []string{}
+	depth := 0
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func splitColumns(s string) []string {
+	columns := []string{}
+	for _, c := range strings.Split(s, ",") {
+		columns = append(columns, strings.Trim(strings.TrimSpace(c), `"`))
+	}
+
+	return columns
+}
+
+func removeConstraint(constraints []Constraint, name string) []Constraint {
+	kept := []Constraint{}
+	for _, c := range constraints {
+		if c.Name != name {
+			kept = append(kept, c)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,499 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Columns managed by the database. They are selected but never written by the API.
+const (
+	ColumnID        = "id"
+	ColumnCreatedAt = "created_at"
+	ColumnUpdatedAt = "updated_at"
+	ColumnDeletedAt = "deleted_at"
+)
+
+// Column describes a table column
+type Column struct {
+	Name       string
+	DataType   string
+	Nullable   bool
+	HasDefault bool
+	Identity   bool
+}
+
+// Constraint is a unique or foreign key constraint
+type Constraint struct {
+	Name    string
+	Columns []string
+
+	// RefTable is referenced table of foreign key
+	RefTable string
+}
+
+// Table describes a table, either parsed from migrations or read from information_schema
+type Table struct {
+	Name        string
+	Columns     []Column
+	Uniques     []Constraint
+	ForeignKeys []Constraint
+
+	// Enums holds values of enum types by type name. Their columns map to string
+	Enums map[string][]string
+
+	// Models maps table name to model name, so foreign keys can reference other models
+	Models map[string]string
+
+	// Required lists nullable columns that API still requires on create
+	Required []string
+
+	// Derived are read-only response fields that are not columns of table. They are nullable.
+	Derived []Column
+
+	// Scope is column or derived field that grid compares with tahun ajaran of period
+	Scope string
+}
+
+// Field is an API writable column with its Go representation
+type Field struct {
+	Column
+
+	// GoName is Go identifier of the column, e.g. IDKelas for id_kelas
+	GoName string
+
+	// CodeName is used to build error codes, e.g. Kelas for id_kelas
+	CodeName string
+
+	// Label is human readable column name used in error messages
+	Label string
+
+	GoType string
+
+	// UpdateType is type of update request field, which tells absent field apart from null and zero
+	UpdateType string
+
+	Required bool
+
+	// Enum holds allowed values when column is an enum
+	Enum []string
+}
+
+// ForeignField is a field that references another table
+type ForeignField struct {
+	Field
+	Constraint string
+	RefTable   string
+
+	refModel string
+}
+
+// UniqueField is a field with single column unique constraint
+type UniqueField struct {
+	Field
+	Constraint string
+}
+
+// Validate checks that table can be served by generated code
+func (t *Table) Validate() error {
+	if len(t.Columns) == 0 {
+		return errors.New("codegen: table " + t.Name + " has no columns")
+	}
+
+	for _, name := range []string{ColumnID, ColumnCreatedAt, ColumnUpdatedAt} {
+		if t.column(name) == nil {
+			return errors.New("codegen: table " + t.Name + " has no " + name + " column")
+		}
+	}
+
+	for _, c := range t.Columns {
+		if _, err := t.goType(c); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range t.Derived {
+		if t.column(c.Name) != nil {
+			return errors.New("codegen: derived field " + c.Name + " is a column of table " + t.Name)
+		}
+		if _, err := t.goType(c); err != nil {
+			return err
+		}
+	}
+
+	if t.Scope != "" {
+		c := t.column(t.Scope)
+		for i := range t.Derived {
+			if t.Derived[i].Name == t.Scope {
+				c = &t.Derived[i]
+			}
+		}
+		if c == nil {
+			return errors.New("codegen: scope " + t.Scope + " is not a column or derived field of table " + t.Name)
+		}
+		if goType, _ := t.goType(Column{Name: c.Name, DataType: c.DataType}); goType != "int" {
+			return errors.New("codegen: scope " + t.Scope + " of table " + t.Name + " is not int")
+		}
+	}
+
+	return nil
+}
+
+// Fields returns columns that API can write, in table order
+func (t *Table) Fields() []Field {
+	fields := []Field{}
+	for _, c := range t.Columns {
+		if isManaged(c.Name) {
+			continue
+		}
+
+		forced := contains(t.Required, c.Name)
+		column := c
+		if forced {
+			column.Nullable = false
+		}
+
+		goType, _ := t.goType(column)
+		f := Field{
+			Column:     c,
+			GoName:     GoName(c.Name),
+			CodeName:   GoName(strings.TrimPrefix(c.Name, "id_")),
+			Label:      strings.Replace(c.Name, "_", " ", -1),
+			GoType:     goType,
+			UpdateType: "optional.Field[" + goType + "]",
+			Required:   forced || (!c.Nullable && !c.HasDefault && !c.Identity),
+		}
+
+		if t.isEnum(strings.ToLower(c.DataType)) {
+			f.Enum = t.Enums[strings.ToLower(c.DataType)]
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+// DerivedFields returns read-only response fields that are not columns of table
+func (t *Table) DerivedFields() []Field {
+	fields := []Field{}
+	for _, c := range t.Derived {
+		goType, _ := t.goType(c)
+		fields = append(fields, Field{
+			Column:   c,
+			GoName:   GoName(c.Name),
+			CodeName: GoName(strings.TrimPrefix(c.Name, "id_")),
+			Label:    strings.Replace(c.Name, "_", " ", -1),
+			GoType:   goType,
+		})
+	}
+
+	return fields
+}
+
+// UniqueFields returns fields that have single column unique constraint
+func (t *Table) UniqueFields() []UniqueField {
+	fields := []UniqueField{}
+	for _, u := range t.Uniques {
+		if len(u.Columns) != 1 {
+			continue
+		}
+		if f := findField(t.Fields(), u.Columns[0]); f != nil {
+			fields = append(fields, UniqueField{Field: *f, Constraint: u.Name})
+		}
+	}
+
+	return fields
+}
+
+// ForeignFields returns int fields that reference another table by single column foreign key
+func (t *Table) ForeignFields() []ForeignField {
+	fields := []ForeignField{}
+	for _, fk := range t.ForeignKeys {
+		if len(fk.Columns) != 1 {
+			continue
+		}
+		if f := findField(t.Fields(), fk.Columns[0]); f != nil && f.GoType == "int" {
+			fields = append(fields, ForeignField{Field: *f, Constraint: fk.Name, RefTable: fk.RefTable, refModel: t.Models[fk.RefTable]})
+		}
+	}
+
+	return fields
+}
+
+// RefModel returns model name of referenced table, e.g. Wali_Kelas for wali_kelas
+func (f ForeignField) RefModel() string {
+	if f.refModel != "" {
+		return f.refModel
+	}
+
+	parts := strings.Split(f.RefTable, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// RefLabel returns human readable name of referenced table
+func (f ForeignField) RefLabel() string {
+	return strings.Replace(f.RefTable, "_", " ", -1)
+}
+
+// SelectColumns returns comma separated column list for SELECT, without soft delete column
+func (t *Table) SelectColumns() string {
+	names := []string{}
+	for _, c := range t.Columns {
+		if c.Name != ColumnDeletedAt {
+			names = append(names, c.Name)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+// InsertColumns returns comma separated column list for INSERT
+func (t *Table) InsertColumns() string {
+	names := []string{}
+	for _, f := range t.Fields() {
+		names = append(names, f.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// InsertPlaceholders returns $1, $2, ... for every inserted column
+func (t *Table) InsertPlaceholders() string {
+	placeholders := []string{}
+	for i := range t.Fields() {
+		placeholders = append(placeholders, "$"+strconv.Itoa(i+1))
+	}
+
+	return strings.Join(placeholders, ", ")
+}
+
+// UpdateAssignments returns col=$1,col=$2,... for every writable column and resets updated_at
+func (t *Table) UpdateAssignments() string {
+	assignments := []string{}
+	for i, f := range t.Fields() {
+		assignments = append(assignments, f.Name+"=$"+strconv.Itoa(i+1))
+	}
+	assignments = append(assignments, ColumnUpdatedAt+"=DEFAULT")
+
+	return strings.Join(assignments, ",")
+}
+
+// IDPlaceholder returns placeholder of id in UPDATE statement
+func (t *Table) IDPlaceholder() string {
+	return "$" + strconv.Itoa(len(t.Fields())+1)
+}
+
+// IsZero returns Go expression that is true when required field of v is not set
+func (f Field) IsZero(v string) string {
+	expr := v + "." + f.GoName
+	switch {
+	case strings.HasPrefix(f.GoType, "*"):
+		return expr + " == nil"
+	case f.GoType == "string":
+		return expr + ` == ""`
+	case f.GoType == "time.Time":
+		return expr + ".IsZero()"
+	default:
+		return expr + " == 0"
+	}
+}
+
+// Validation returns validate tag value of create request field
+func (f Field) Validation() string {
+	if f.Required && f.GoType != "bool" {
+		return "required"
+	}
+
+	return ""
+}
+
+// ZeroValue returns Go expression of zero value of field
+func (f Field) ZeroValue() string {
+	switch {
+	case f.Pointer():
+		return "nil"
+	case f.GoType == "string":
+		return `""`
+	case f.GoType == "bool":
+		return "false"
+	case f.GoType == "time.Time":
+		return "time.Time{}"
+	default:
+		return "0"
+	}
+}
+
+// Pointer returns true when field is nullable in Go
+func (f Field) Pointer() bool {
+	return strings.HasPrefix(f.GoType, "*")
+}
+
+// Comparable returns true when field can be compared with == after a database round trip.
+// Time loses precision and location, so it is not compared.
+func (f Field) Comparable() bool {
+	return !f.Pointer() && f.GoType != "time.Time"
+}
+
+// Sample returns Go expression of a test value of field, unique for prefix and n
+func (f Field) Sample(prefix string, n string) string {
+	if len(f.Enum) > 0 {
+		return strconv.Quote(f.Enum[0])
+	}
+
+	switch f.GoType {
+	case "string":
+		return `fmt.Sprintf("%s-` + f.Name + `-%d", ` + prefix + ", " + n + ")"
+	case "int64":
+		return "int64(" + n + ")"
+	case "float64":
+		return "float64(" + n + ") + 0.5"
+	case "bool":
+		return n + "%2 == 0"
+	case "time.Time":
+		return "time.Date(2020, 1, " + n + ", 0, 0, 0, 0, time.UTC)"
+	default:
+		return n
+	}
+}
+
+// Foreign returns foreign key of field, or nil when field does not reference another table
+func (t *Table) Foreign(column string) *ForeignField {
+	for _, f := range t.ForeignFields() {
+		if f.Name == column {
+			return &f
+		}
+	}
+
+	return nil
+}
+
+// SearchField returns first required string field, which generated tests filter and sort by.
+// Sample values of the field contain test prefix. Nil when table has no such field.
+func (t *Table) SearchField() *Field {
+	for _, f := range t.Fields() {
+		if f.Required && f.GoType == "string" && len(f.Enum) == 0 {
+			return &f
+		}
+	}
+
+	return nil
+}
+
+// UsesFormat returns true when sample value of any field is formatted with fmt
+func (t *Table) UsesFormat() bool {
+	for _, f := range t.Fields() {
+		if f.GoType == "string" && len(f.Enum) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Table) isEnum(dataType string) bool {
+	_, ok := t.Enums[dataType]
+	return ok
+}
+
+func (t *Table) column(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) goType(c Column) (string, error) {
+	dataType := strings.ToLower(strings.TrimSpace(c.DataType))
+	if i := strings.Index(dataType, "("); i > -1 {
+		dataType = strings.TrimSpace(dataType[:i])
+	}
+
+	goType := ""
+	switch {
+	case dataType == "int" || dataType == "integer" || dataType == "int4" ||
+		dataType == "smallint" || dataType == "int2" || dataType == "serial":
+		goType = "int"
+	case dataType == "bigint" || dataType == "int8" || dataType == "bigserial":
+		goType = "int64"
+	case dataType == "text" || dataType == "varchar" || dataType == "character varying" ||
+		dataType == "char" || dataType == "character" || dataType == "uuid" ||
+		dataType == "user-defined" || t.isEnum(dataType):
+		goType = "string"
+	case dataType == "boolean" || dataType == "bool":
+		goType = "bool"
+	case dataType == "numeric" || dataType == "decimal" || dataType == "real" ||
+		dataType == "double precision" || dataType == "float4" || dataType == "float8":
+		goType = "float64"
+	case strings.HasPrefix(dataType, "timestamp") || dataType == "date":
+		goType = "time.Time"
+	default:
+		return "", errors.New("codegen: unsupported type " + c.DataType + " of column " + t.Name + "." + c.Name)
+	}
+
+	if c.Nullable {
+		goType = "*" + goType
+	}
+
+	return goType, nil
+}
+
+// GoName converts snake_case column name to Go identifier, keeping initialisms upper case
+func GoName(column string) string {
+	name := ""
+	for _, part := range strings.Split(column, "_") {
+		if part == "" {
+			continue
+		}
+		if initialism, ok := initialisms[part]; ok {
+			name += initialism
+			continue
+		}
+		name += strings.ToUpper(part[:1]) + part[1:]
+	}
+
+	return name
+}
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"url":  "URL",
+	"api":  "API",
+	"uuid": "UUID",
+	"nis":  "NIS",
+	"nip":  "NIP",
+}
+
+func isManaged(column string) bool {
+	return column == ColumnID || column == ColumnCreatedAt || column == ColumnUpdatedAt || column == ColumnDeletedAt
+}
+
+func findField(fields []Field, column string) *Field {
+	for i := range fields {
+		if fields[i].Name == column {
+			return &fields[i]
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,696 @@
+// Package crud implements create, get, list, update and delete of one table in PostgreSQL for entity
+// services. Service describes its table and API errors with Entity, and keeps only its domain rules,
+// in Hooks.
+//
+// Repository is parameterized by create request C, update request U and response R of entity.
+// Columns are db tags of R. Fields of C and U are matched to fields of R by Go name, so C lists
+// columns that are written. Field of U is optional.Field of type of R field, so update tells absent
+// fields apart from null and zero ones. R has id, created_at and updated_at columns. Derived fields
+// of R are selected with SQL expressions instead of columns, and are never written.
+//
+// Usage:
+//
+//	repository := crud.New[schema.CreateKelasRequest, schema.UpdateKelasRequest, schema.KelasResponse](db, kelasEntity, hooks)
+//	kelas, err := repository.Create(ctx, request)
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+	"github.com/syukur91/ischool-monitor/pkg/precondition"
+	"github.com/syukur91/ischool-monitor/pkg/query"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+type (
+	// Entity describes table of entity and API errors of its operations
+	Entity struct {
+		// Model names spans and error messages, e.g. Wali_Kelas
+		Model string
+
+		// LowerCase names wrapped errors, e.g. wali_kelas
+		LowerCase string
+
+		// Table is table name in public schema
+		Table string
+
+		IDRequired apierror.Code
+		NotFound   apierror.Code
+
+		// Required lists create request fields that must be set
+		Required []Required
+
+		// Uniques and ForeignKeys map violated constraints to API errors
+		Uniques     []Unique
+		ForeignKeys []ForeignKey
+
+		// Derived are read-only fields of R that are not columns of table
+		Derived []Derived
+	}
+
+	// Required is create request field that must not be zero
+	Required struct {
+		Field string
+		Label string
+		Code  apierror.Code
+
+		// Nullable is true when column is nullable and only create requires it, so update may clear
+		// it with zero value or null
+		Nullable bool
+	}
+
+	// Unique is unique constraint
+	Unique struct {
+		Constraint string
+		Label      string
+		Code       apierror.Code
+	}
+
+	// ForeignKey is foreign key constraint of Field, which references RefModel
+	ForeignKey struct {
+		Constraint string
+		Field      string
+		RefModel   string
+		RefLabel   string
+		Code       apierror.Code
+	}
+
+	// Derived is nullable field of R with db tag Column, which is selected with SQL expression
+	// Select over row of table, e.g. wali kelas of siswa:
+	//
+	//	SELECT k.id_wali_kelas FROM public.kelas_wali_kelas k WHERE k.id_kelas=siswa.id_kelas
+	Derived struct {
+		Column string
+		Select string
+	}
+
+	// Hooks are domain rules of entity. Both are optional. They run in transaction of operation, which
+	// their ctx carries, so queries they run with dbtx.WithTx see and roll back with the operation.
+	Hooks[C any, R any] struct {
+		// ValidateCreate is called before row is inserted, after required fields are checked
+		ValidateCreate func(ctx context.Context, request *C) error
+
+		// ValidateUpdate is called with existing row merged with update request, or replaced by
+		// replace request, before it is saved
+		ValidateUpdate func(ctx context.Context, row *R) error
+	}
+
+	// Repository implements CRUD of entity
+	Repository[C any, U any, R any] struct {
+		db     *sqlx.DB
+		entity Entity
+		hooks  Hooks[C, R]
+
+		// columns are written columns, in order of create request fields
+		columns []column
+
+		// selectColumns selects every field of R from table, names lists their names
+		selectColumns string
+		names         string
+
+		// derived selects derived fields, returned by insert and update
+		derived []derived
+
+		id        []int
+		createdAt []int
+		updatedAt []int
+	}
+
+	// column is written column with index of its field in C, U and R
+	column struct {
+		name     string
+		field    string
+		label    string
+		nullable bool
+		create   []int
+		update   []int
+		row      []int
+	}
+
+	// derived is derived field with index of its field in R
+	derived struct {
+		name string
+		expr string
+		row  []int
+	}
+)
+
+// New returns repository of entity. It panics when C, U and R don't match, which is a programming
+// error found on start.
+func New[C any, U any, R any](db *sqlx.DB, entity Entity, hooks Hooks[C, R]) *Repository[C, U, R] {
+	r := &Repository[C, U, R]{db: db, entity: entity, hooks: hooks}
+
+	createType := reflect.TypeOf((*C)(nil)).Elem()
+	updateType := reflect.TypeOf((*U)(nil)).Elem()
+	rowType := reflect.TypeOf((*R)(nil)).Elem()
+
+	derivedSelects := map[string]string{}
+	for _, d := range entity.Derived {
+		derivedSelects[d.Column] = d.Select
+	}
+
+	rowColumns := map[string][]int{}
+	selectColumns := []string{}
+	names := []string{}
+	for i := 0; i < rowType.NumField(); i++ {
+		f := rowType.Field(i)
+		name := strings.Split(f.Tag.Get("db"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+
+		if s, ok := derivedSelects[name]; ok {
+			if f.Type.Kind() != reflect.Ptr {
+				panic("crud: derived field " + rowType.Name() + "." + f.Name + " must be nullable")
+			}
+			r.derived = append(r.derived, derived{name: name, expr: "(" + s + ") AS " + name, row: f.Index})
+			selectColumns = append(selectColumns, "("+s+") AS "+name)
+			delete(derivedSelects, name)
+			continue
+		}
+
+		rowColumns[name] = f.Index
+		selectColumns = append(selectColumns, name)
+	}
+	r.selectColumns = strings.Join(selectColumns, ",")
+	r.names = strings.Join(names, ",")
+
+	for name := range derivedSelects {
+		panic("crud: " + rowType.Name() + " has no derived field " + name)
+	}
+
+	r.id = rowField(rowType, rowColumns, "id", reflect.TypeOf(0))
+	r.createdAt = rowField(rowType, rowColumns, "created_at", reflect.TypeOf(&time.Time{}))
+	r.updatedAt = rowField(rowType, rowColumns, "updated_at", reflect.TypeOf(&time.Time{}))
+
+	for i := 0; i < createType.NumField(); i++ {
+		f := createType.Field(i)
+		rf, ok := rowType.FieldByName(f.Name)
+		if !ok || !f.Type.AssignableTo(rf.Type) {
+			panic("crud: " + rowType.Name() + " has no field " + f.Name + " of type " + f.Type.String())
+		}
+		name := strings.Split(rf.Tag.Get("db"), ",")[0]
+		if _, ok := rowColumns[name]; !ok {
+			panic("crud: field " + rowType.Name() + "." + f.Name + " is not a column")
+		}
+
+		c := column{name: name, field: f.Name, label: strings.Replace(name, "_", " ", -1), nullable: rf.Type.Kind() == reflect.Ptr, create: f.Index, row: rf.Index}
+		if uf, ok := updateType.FieldByName(f.Name); ok {
+			o, ok := reflect.Zero(uf.Type).Interface().(optional.Optional)
+			if !ok || o.ValueType() != rf.Type {
+				panic("crud: field " + updateType.Name() + "." + f.Name + " must be optional.Field[" + rf.Type.String() + "]")
+			}
+			c.update = uf.Index
+		}
+		r.columns = append(r.columns, c)
+	}
+
+	for _, f := range entity.Required {
+		if _, ok := createType.FieldByName(f.Field); !ok {
+			panic("crud: required field " + createType.Name() + "." + f.Field + " does not exist")
+		}
+	}
+	for _, fk := range entity.ForeignKeys {
+		if _, ok := rowType.FieldByName(fk.Field); !ok {
+			panic("crud: foreign key field " + rowType.Name() + "." + fk.Field + " does not exist")
+		}
+	}
+
+	return r
+}
+
+// rowField returns index of column field of R, which must have type t
+func rowField(rowType reflect.Type, rowColumns map[string][]int, name string, t reflect.Type) []int {
+	index, ok := rowColumns[name]
+	if !ok || rowType.FieldByIndex(index).Type != t {
+		panic("crud: " + rowType.Name() + " has no column " + name + " of type " + t.String())
+	}
+
+	return index
+}
+
+// Create inserts row of request
+func (r *Repository[C, U, R]) Create(ctx context.Context, request *C) (_ *R, err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.Create"+r.entity.Model)
+	defer func() { tracing.End(span, err) }()
+
+	fn := "create" + r.entity.LowerCase
+	values := reflect.ValueOf(request).Elem()
+	err = r.required(fn, values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	row := new(R)
+	rowValue := reflect.ValueOf(row).Elem()
+	names := []string{}
+	placeholders := []string{}
+	args := []interface{}{}
+	for i, c := range r.columns {
+		v := values.FieldByIndex(c.create)
+		rowValue.FieldByIndex(c.row).Set(v)
+		names = append(names, c.name)
+		placeholders = append(placeholders, "$"+strconv.Itoa(i+1))
+		args = append(args, v.Interface())
+	}
+
+	id := 0
+	var createdAt time.Time
+	err = dbtx.WithTx(ctx, r.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		if r.hooks.ValidateCreate != nil {
+			err := r.hooks.ValidateCreate(ctx, request)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.`+r.entity.Table+` (`+strings.Join(names, ",")+`)
+			VALUES(`+strings.Join(placeholders, ",")+`)
+			RETURNING id, created_at`+r.returningDerived()+`;`,
+			args...).Scan(r.scanDerived(rowValue, &id, &createdAt)...)
+
+		if err != nil {
+			return r.writeError(err, fn, rowValue, "exec insert statement failed")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, txError(err, fn)
+	}
+
+	rowValue.FieldByIndex(r.id).SetInt(int64(id))
+	rowValue.FieldByIndex(r.createdAt).Set(reflect.ValueOf(&createdAt))
+
+	return row, nil
+}
+
+// Get returns row with id
+func (r *Repository[C, U, R]) Get(ctx context.Context, id string) (_ *R, err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.Get"+r.entity.Model)
+	defer func() { tracing.End(span, err) }()
+
+	fn := "get" + r.entity.LowerCase
+	if id == "" {
+		return nil, r.idRequired(fn)
+	}
+
+	var row *R
+	err = dbtx.WithTx(ctx, r.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		var err error
+		row, err = r.get(ctx, tx, id, fn, false)
+		return err
+	})
+	if err != nil {
+		return nil, txError(err, fn)
+	}
+
+	return row, nil
+}
+
+// List returns page of rows and total of rows that match grid filter
+func (r *Repository[C, U, R]) List(ctx context.Context, gridParams *query.GridParams) (_ []R, _ int, err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.List"+r.entity.Model+"s", tracing.GridAttributes(gridParams))
+	defer func() { tracing.End(span, err) }()
+
+	fn := "list" + r.entity.LowerCase
+	rows := []R{}
+	total := 0
+	err = dbtx.WithTx(ctx, r.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		dataStatement := "SELECT " + r.names + " FROM " + r.from()
+		dataQuery, dataParams := query.FullQuery(gridParams, "", nil)
+		err := tx.SelectContext(ctx, &rows, dataStatement+dataQuery, dataParams...)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get data failed"))
+		}
+
+		countStatement := "SELECT count(*) FROM " + r.from()
+		countQuery, countParams := query.FilterQuery(gridParams, "", nil)
+		err = tx.QueryRowContext(ctx, countStatement+countQuery, countParams...).Scan(&total)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get count failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, txError(err, fn)
+	}
+
+	return rows, total, nil
+}
+
+// Update sets fields of row with id that are set in request, as JSON Merge Patch does. Absent
+// fields keep their value, null ones are set to NULL.
+func (r *Repository[C, U, R]) Update(ctx context.Context, id string, request *U) (_ *R, err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.Update"+r.entity.Model)
+	defer func() { tracing.End(span, err) }()
+
+	fn := "update" + r.entity.LowerCase
+	return r.save(ctx, id, fn, func(row reflect.Value) error {
+		return r.merge(fn, row, reflect.ValueOf(request).Elem())
+	})
+}
+
+// Replace sets every column of row with id to field of request, so fields that are not set are
+// cleared
+func (r *Repository[C, U, R]) Replace(ctx context.Context, id string, request *C) (_ *R, err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.Replace"+r.entity.Model)
+	defer func() { tracing.End(span, err) }()
+
+	fn := "replace" + r.entity.LowerCase
+	values := reflect.ValueOf(request).Elem()
+	err = r.required(fn, values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.save(ctx, id, fn, func(row reflect.Value) error {
+		for _, c := range r.columns {
+			row.FieldByIndex(c.row).Set(values.FieldByIndex(c.create))
+		}
+		return nil
+	})
+}
+
+// save locks row with id, changes it with apply and writes every column of it. It fails when
+// If-Match of request does not match the row.
+func (r *Repository[C, U, R]) save(ctx context.Context, id string, fn string, apply func(row reflect.Value) error) (*R, error) {
+	if id == "" {
+		return nil, r.idRequired(fn)
+	}
+
+	var row *R
+	var updatedAt time.Time
+	err := dbtx.WithTx(ctx, r.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		// get existing row, locked until it is written
+		var err error
+		row, err = r.get(ctx, tx, id, fn, true)
+		if err != nil {
+			return err
+		}
+
+		rowValue := reflect.ValueOf(row).Elem()
+		err = r.precondition(ctx, fn, id, rowValue)
+		if err != nil {
+			return err
+		}
+
+		// update row
+		err = apply(rowValue)
+		if err != nil {
+			return err
+		}
+
+		err = r.required(fn, rowValue, true)
+		if err != nil {
+			return err
+		}
+
+		if r.hooks.ValidateUpdate != nil {
+			err = r.hooks.ValidateUpdate(ctx, row)
+			if err != nil {
+				return err
+			}
+		}
+
+		assignments := []string{}
+		args := []interface{}{}
+		for i, c := range r.columns {
+			assignments = append(assignments, c.name+"=$"+strconv.Itoa(i+1))
+			args = append(args, rowValue.FieldByIndex(c.row).Interface())
+		}
+		args = append(args, id)
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE public.`+r.entity.Table+` SET `+strings.Join(assignments, ",")+`,updated_at=clock_timestamp()
+			WHERE id=$`+strconv.Itoa(len(args))+` returning updated_at`+r.returningDerived(),
+			args...).Scan(r.scanDerived(rowValue, &updatedAt)...)
+
+		if err != nil {
+			return r.writeError(err, fn, rowValue, "update data failed")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, txError(err, fn)
+	}
+
+	reflect.ValueOf(row).Elem().FieldByIndex(r.updatedAt).Set(reflect.ValueOf(&updatedAt))
+
+	return row, nil
+}
+
+// Delete deletes row with id. It fails when If-Match of request does not match the row.
+func (r *Repository[C, U, R]) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, r.entity.Model+"Service.Delete"+r.entity.Model)
+	defer func() { tracing.End(span, err) }()
+
+	fn := "delete" + r.entity.LowerCase
+	if id == "" {
+		return r.idRequired(fn)
+	}
+
+	var rows int64
+	err = dbtx.WithTx(ctx, r.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		// lock existing row to compare it with If-Match of request
+		row, err := r.get(ctx, tx, id, fn, true)
+		if err != nil {
+			return err
+		}
+
+		err = r.precondition(ctx, fn, id, reflect.ValueOf(row).Elem())
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM public.`+r.entity.Table+`
+			WHERE id=$1`,
+			id)
+
+		if err != nil {
+			return r.deleteError(err, fn, id)
+		}
+
+		rows, err = result.RowsAffected()
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get deleted rows failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return txError(err, fn)
+	}
+
+	if rows == 0 {
+		return r.notFound(fn, id, nil)
+	}
+
+	return nil
+}
+
+// get returns row with id in tx. Lock locks it until tx ends.
+func (r *Repository[C, U, R]) get(ctx context.Context, tx *sqlx.Tx, id string, fn string, lock bool) (*R, error) {
+	forUpdate := ""
+	if lock {
+		forUpdate = " FOR UPDATE"
+	}
+
+	row := new(R)
+	err := tx.GetContext(ctx, row, `
+		SELECT `+r.selectColumns+`
+		FROM public.`+r.entity.Table+`
+		WHERE id=$1`+forUpdate+`;`,
+		id)
+
+	if err == sql.ErrNoRows {
+		return nil, r.notFound(fn, id, err)
+	}
+	if err != nil {
+		return nil, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get data failed"))
+	}
+
+	return row, nil
+}
+
+// from returns FROM of list. Table with derived fields is wrapped in subquery of the same name,
+// so grid filters and sorts them like columns.
+func (r *Repository[C, U, R]) from() string {
+	if len(r.derived) == 0 {
+		return "public." + r.entity.Table
+	}
+
+	return "(SELECT " + r.selectColumns + " FROM public." + r.entity.Table + ") AS " + r.entity.Table
+}
+
+// returningDerived returns derived fields for RETURNING of insert and update
+func (r *Repository[C, U, R]) returningDerived() string {
+	selects := ""
+	for _, d := range r.derived {
+		selects += ", " + d.expr
+	}
+
+	return selects
+}
+
+// scanDerived returns dest followed by derived fields of row, as RETURNING of returningDerived
+// lists them
+func (r *Repository[C, U, R]) scanDerived(row reflect.Value, dest ...interface{}) []interface{} {
+	for _, d := range r.derived {
+		dest = append(dest, row.FieldByIndex(d.row).Addr().Interface())
+	}
+
+	return dest
+}
+
+// precondition compares If-Match of request with ETag of locked row
+func (r *Repository[C, U, R]) precondition(ctx context.Context, fn string, id string, row reflect.Value) error {
+	return precondition.Check(ctx, fn, r.entity.Model+" with id: "+id, precondition.ETag(row.Interface()))
+}
+
+// txError returns error of unit of work unchanged, and error of transaction as database error
+func txError(err error, fn string) error {
+	if _, ok := err.(*apierror.APIError); ok {
+		return err
+	}
+
+	return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn))
+}
+
+// required returns error of first required field of values that is zero. Values is create request
+// or row, which have the same field names. Update leaves out nullable fields, which it may clear.
+func (r *Repository[C, U, R]) required(fn string, values reflect.Value, update bool) error {
+	for _, f := range r.entity.Required {
+		if update && f.Nullable {
+			continue
+		}
+		if values.FieldByName(f.Field).IsZero() {
+			return r.notSet(fn, f)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository[C, U, R]) notSet(fn string, f Required) error {
+	return apierror.NewError(http.StatusBadRequest, f.Code, r.entity.Model+" "+f.Label+" is not set", errors.New(fn+": "+r.entity.LowerCase+" "+f.Label+" is not set"))
+}
+
+func (r *Repository[C, U, R]) idRequired(fn string) error {
+	return apierror.NewError(http.StatusBadRequest, r.entity.IDRequired, r.entity.Model+" id is not set", errors.New(fn+": "+r.entity.LowerCase+" id is not set"))
+}
+
+func (r *Repository[C, U, R]) notFound(fn string, id string, err error) error {
+	message := fn + ": " + r.entity.LowerCase + " with id: " + id + " is not exists"
+	cause := errors.New(message)
+	if err != nil {
+		cause = errors.Wrap(err, message)
+	}
+
+	return apierror.NewError(http.StatusNotFound, r.entity.NotFound, r.entity.Model+" with id: "+id+" is not exists", cause)
+}
+
+// deleteError maps error of delete of row that other rows still reference to conflict
+func (r *Repository[C, U, R]) deleteError(err error, fn string, id string) error {
+	if strings.Index(err.Error(), "violates foreign key constraint") > -1 {
+		return apierror.NewError(http.StatusConflict, apierror.StillReferenced, r.entity.Model+" with id: "+id+" is still used by other data. Remove them first", errors.Wrap(err, fn+": "+r.entity.LowerCase+" is still referenced"))
+	}
+
+	return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": delete data failed"))
+}
+
+// writeError maps error of insert or update of row to error of violated constraint
+func (r *Repository[C, U, R]) writeError(err error, fn string, row reflect.Value, failed string) error {
+	for _, u := range r.entity.Uniques {
+		if strings.Index(err.Error(), "duplicate key value violates unique constraint \""+u.Constraint+"\"") > -1 {
+			return apierror.NewError(http.StatusBadRequest, u.Code, r.entity.Model+" with same "+u.Label+" already exists. Use different "+u.Label, errors.Wrap(err, fn+": "+r.entity.Model+" with same "+u.Label+" already exists"))
+		}
+	}
+
+	for _, fk := range r.entity.ForeignKeys {
+		if strings.Index(err.Error(), "violates foreign key constraint \""+fk.Constraint+"\"") > -1 {
+			return apierror.NewError(http.StatusBadRequest, fk.Code, fk.RefModel+" with id: "+text(row.FieldByName(fk.Field))+" is not exists", errors.Wrap(err, fn+": "+fk.RefLabel+" is not exists"))
+		}
+	}
+
+	return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": "+failed))
+}
+
+// merge sets columns of row to fields of request that are set. Null clears nullable column, to nil
+// or to zero value of required field, and fails for the others.
+func (r *Repository[C, U, R]) merge(fn string, row reflect.Value, request reflect.Value) error {
+	for _, c := range r.columns {
+		if c.update == nil {
+			continue
+		}
+
+		v := request.FieldByIndex(c.update).Interface().(optional.Optional)
+		if !v.IsSet() {
+			continue
+		}
+
+		field := row.FieldByIndex(c.row)
+		if !v.IsNull() {
+			field.Set(reflect.ValueOf(v.Interface()))
+			continue
+		}
+
+		err := r.nullable(fn, c)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.Zero(field.Type()))
+	}
+
+	return nil
+}
+
+// nullable returns error when column can not be set to null. Column of pointer field is nullable,
+// column of required field when it is nullable in table, which null clears to zero value.
+func (r *Repository[C, U, R]) nullable(fn string, c column) error {
+	if c.nullable {
+		return nil
+	}
+
+	for _, f := range r.entity.Required {
+		if f.Field != c.field {
+			continue
+		}
+		if f.Nullable {
+			return nil
+		}
+		return r.notSet(fn, f)
+	}
+
+	return apierror.NewError(http.StatusBadRequest, apierror.RequestInvalid, r.entity.Model+" "+c.label+" can not be null", errors.New(fn+": "+r.entity.LowerCase+" "+c.label+" can not be null"))
+}
+
+// text formats value of field for error message
+func text(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "null"
+		}
+		v = v.Elem()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,403 @@
+package crud
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+type testCreate struct {
+	Nama    string
+	Tingkat int
+	Catatan *string
+	Alamat  string
+}
+
+type testUpdate struct {
+	Nama    optional.Field[string]
+	Tingkat optional.Field[int]
+	Catatan optional.Field[*string]
+	Alamat  optional.Field[string]
+}
+
+type testRow struct {
+	ID        int        `db:"id"`
+	Nama      string     `db:"nama"`
+	Tingkat   int        `db:"tingkat"`
+	Catatan   *string    `db:"catatan"`
+	Alamat    string     `db:"alamat"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type testRowWithoutCatatan struct {
+	ID        int        `db:"id"`
+	Nama      string     `db:"nama"`
+	Tingkat   int        `db:"tingkat"`
+	Alamat    string     `db:"alamat"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type testRowWithDerived struct {
+	ID        int        `db:"id"`
+	Nama      string     `db:"nama"`
+	Tingkat   int        `db:"tingkat"`
+	Catatan   *string    `db:"catatan"`
+	Alamat    string     `db:"alamat"`
+	IDGuru    *int       `db:"id_guru"`
+	Guru      int        `db:"guru"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type testUpdateWrongType struct {
+	Tingkat optional.Field[string]
+}
+
+type testUpdateNotOptional struct {
+	Tingkat *int
+}
+
+type testRowWithoutUpdatedAt struct {
+	ID        int        `db:"id"`
+	Nama      string     `db:"nama"`
+	Tingkat   int        `db:"tingkat"`
+	Catatan   *string    `db:"catatan"`
+	Alamat    string     `db:"alamat"`
+	CreatedAt *time.Time `db:"created_at"`
+}
+
+var testEntity = Entity{
+	Model:      "Test",
+	LowerCase:  "test",
+	Table:      "test",
+	IDRequired: apierror.RequestInvalid,
+	NotFound:   apierror.RequestInvalid,
+	Required: []Required{
+		{Field: "Nama", Label: "nama", Code: apierror.RequestInvalid},
+		{Field: "Alamat", Label: "alamat", Code: apierror.RequestInvalid, Nullable: true},
+	},
+}
+
+func TestNew(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName  string
+		new           func()
+		expectedPanic bool
+	}{
+		{
+			scenarioName: "matching types",
+			new: func() {
+				New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRow]{})
+			},
+		},
+		{
+			scenarioName: "create field missing in row",
+			new: func() {
+				New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRowWithoutCatatan]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "update field of other type",
+			new: func() {
+				New[testCreate, testUpdateWrongType](nil, testEntity, Hooks[testCreate, testRow]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "update field that is not optional",
+			new: func() {
+				New[testCreate, testUpdateNotOptional](nil, testEntity, Hooks[testCreate, testRow]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "row without updated_at",
+			new: func() {
+				New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRowWithoutUpdatedAt]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "derived field",
+			new: func() {
+				entity := testEntity
+				entity.Derived = []Derived{{Column: "id_guru", Select: "SELECT 1"}}
+				New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRowWithDerived]{})
+			},
+		},
+		{
+			scenarioName: "derived field that is not nullable",
+			new: func() {
+				entity := testEntity
+				entity.Derived = []Derived{{Column: "guru", Select: "SELECT 1"}}
+				New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRowWithDerived]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "unknown derived field",
+			new: func() {
+				entity := testEntity
+				entity.Derived = []Derived{{Column: "umur", Select: "SELECT 1"}}
+				New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRowWithDerived]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "derived field in create request",
+			new: func() {
+				entity := testEntity
+				entity.Derived = []Derived{{Column: "catatan", Select: "SELECT 'catatan'"}}
+				New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRow]{})
+			},
+			expectedPanic: true,
+		},
+		{
+			scenarioName: "unknown required field",
+			new: func() {
+				entity := testEntity
+				entity.Required = []Required{{Field: "Umur", Label: "umur", Code: apierror.RequestInvalid}}
+				New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRow]{})
+			},
+			expectedPanic: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			panicked := func() (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+				v.new()
+				return false
+			}()
+
+			if panicked != v.expectedPanic {
+				t.Errorf("expect panic %t, but got %t", v.expectedPanic, panicked)
+				return
+			}
+		})
+	}
+}
+
+func TestNew_Columns(t *testing.T) {
+	r := New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRow]{})
+
+	expectedSelect := "id,nama,tingkat,catatan,alamat,created_at,updated_at"
+	if r.selectColumns != expectedSelect {
+		t.Errorf("expect select columns %s, but got %s", expectedSelect, r.selectColumns)
+		return
+	}
+
+	names := []string{}
+	for _, c := range r.columns {
+		names = append(names, c.name)
+	}
+	expectedNames := []string{"nama", "tingkat", "catatan", "alamat"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("expect written columns %v, but got %v", expectedNames, names)
+		return
+	}
+
+	expectedFrom := "public.test"
+	if r.from() != expectedFrom {
+		t.Errorf("expect from %s, but got %s", expectedFrom, r.from())
+		return
+	}
+}
+
+func TestNew_Derived(t *testing.T) {
+	entity := testEntity
+	entity.Derived = []Derived{{Column: "id_guru", Select: "SELECT g.id FROM public.guru g WHERE g.id_kelas=test.id"}}
+	r := New[testCreate, testUpdate](nil, entity, Hooks[testCreate, testRowWithDerived]{})
+
+	expectedSelect := "id,nama,tingkat,catatan,alamat,(SELECT g.id FROM public.guru g WHERE g.id_kelas=test.id) AS id_guru,guru,created_at,updated_at"
+	if r.selectColumns != expectedSelect {
+		t.Errorf("expect select columns %s, but got %s", expectedSelect, r.selectColumns)
+		return
+	}
+
+	expectedFrom := "(SELECT " + expectedSelect + " FROM public.test) AS test"
+	if r.from() != expectedFrom {
+		t.Errorf("expect from %s, but got %s", expectedFrom, r.from())
+		return
+	}
+
+	expectedReturning := ", (SELECT g.id FROM public.guru g WHERE g.id_kelas=test.id) AS id_guru"
+	if r.returningDerived() != expectedReturning {
+		t.Errorf("expect returning %s, but got %s", expectedReturning, r.returningDerived())
+		return
+	}
+
+	id := 0
+	row := testRowWithDerived{}
+	dest := r.scanDerived(reflect.ValueOf(&row).Elem(), &id)
+	if len(dest) != 2 || dest[1] != &row.IDGuru {
+		t.Errorf("expect id and id guru of row to be scanned, but got %v", dest)
+		return
+	}
+}
+
+func TestMerge(t *testing.T) {
+	r := New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRow]{})
+
+	catatan, newCatatan := "pindahan", "aktif"
+
+	testScenarios := []struct {
+		scenarioName    string
+		request         testUpdate
+		expected        testRow
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "nothing set keeps row",
+			request:      testUpdate{},
+			expected:     testRow{ID: 1, Nama: "Budi", Tingkat: 1, Catatan: &catatan, Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName: "value field",
+			request:      testUpdate{Nama: optional.Of("Bayu")},
+			expected:     testRow{ID: 1, Nama: "Bayu", Tingkat: 1, Catatan: &catatan, Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName: "zero value",
+			request:      testUpdate{Tingkat: optional.Of(0)},
+			expected:     testRow{ID: 1, Nama: "Budi", Tingkat: 0, Catatan: &catatan, Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName: "pointer field",
+			request:      testUpdate{Catatan: optional.Of(&newCatatan)},
+			expected:     testRow{ID: 1, Nama: "Budi", Tingkat: 1, Catatan: &newCatatan, Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName: "null clears pointer field",
+			request:      testUpdate{Catatan: optional.Null[*string]()},
+			expected:     testRow{ID: 1, Nama: "Budi", Tingkat: 1, Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName: "null clears nullable required field",
+			request:      testUpdate{Alamat: optional.Null[string]()},
+			expected:     testRow{ID: 1, Nama: "Budi", Tingkat: 1, Catatan: &catatan},
+		},
+		{
+			scenarioName:    "null of required field",
+			request:         testUpdate{Nama: optional.Null[string]()},
+			expectedErrCode: apierror.RequestInvalid,
+		},
+		{
+			scenarioName:    "null of value field",
+			request:         testUpdate{Tingkat: optional.Null[int]()},
+			expectedErrCode: apierror.RequestInvalid,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			row := testRow{ID: 1, Nama: "Budi", Tingkat: 1, Catatan: &catatan, Alamat: "Jl. Merdeka"}
+			err := r.merge("updatetest", reflect.ValueOf(&row).Elem(), reflect.ValueOf(&v.request).Elem())
+
+			errCode := apierror.Code("")
+			if err != nil {
+				errCode = err.(*apierror.APIError).Code
+			}
+			if errCode != v.expectedErrCode {
+				t.Errorf("expect error code %s, but got %v", v.expectedErrCode, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(row, v.expected) {
+				t.Errorf("expect row %+v, but got %+v", v.expected, row)
+				return
+			}
+		})
+	}
+}
+
+func TestRequired(t *testing.T) {
+	r := New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRow]{})
+
+	testScenarios := []struct {
+		scenarioName    string
+		row             testRow
+		update          bool
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "required field is set",
+			row:          testRow{Nama: "Budi", Alamat: "Jl. Merdeka"},
+		},
+		{
+			scenarioName:    "required field is cleared",
+			row:             testRow{Nama: "", Tingkat: 1},
+			expectedErrCode: apierror.RequestInvalid,
+		},
+		{
+			scenarioName: "update clears nullable required field",
+			row:          testRow{Nama: "Budi"},
+			update:       true,
+		},
+		{
+			scenarioName:    "create without nullable required field",
+			row:             testRow{Nama: "Budi"},
+			expectedErrCode: apierror.RequestInvalid,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			err := r.required("updatetest", reflect.ValueOf(&v.row).Elem(), v.update)
+
+			errCode := apierror.Code("")
+			if err != nil {
+				errCode = err.(*apierror.APIError).Code
+			}
+			if errCode != v.expectedErrCode {
+				t.Errorf("expect error code %s, but got %v", v.expectedErrCode, err)
+				return
+			}
+		})
+	}
+}
+
+func TestDeleteError(t *testing.T) {
+	r := New[testCreate, testUpdate](nil, testEntity, Hooks[testCreate, testRow]{})
+
+	testScenarios := []struct {
+		scenarioName    string
+		err             error
+		expectedStatus  int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName:    "row referenced by other table",
+			err:             errors.New(`pq: update or delete on table "test" violates foreign key constraint "test_other_id_test_foreign" on table "other"`),
+			expectedStatus:  http.StatusConflict,
+			expectedErrCode: apierror.StillReferenced,
+		},
+		{
+			scenarioName:    "other database error",
+			err:             errors.New("pq: canceling statement due to statement timeout"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedErrCode: apierror.DatabaseError,
+		},
+	}
+
+	for _, v := range testScenarios {
+		err := r.deleteError(v.err, "deletetest", "1").(*apierror.APIError)
+
+		if err.HTTPStatus != v.expectedStatus || err.Code != v.expectedErrCode {
+			t.Errorf("%s: expect %d %s, but got %d %s", v.scenarioName, v.expectedStatus, v.expectedErrCode, err.HTTPStatus, err.Code)
+			return
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+// TestNew_Services checks that entities of services match their schema, without database
+func TestNew_Services(t *testing.T) {
+	db := sqlx.NewDb(nil, "postgres")
+
+	testScenarios := []struct {
+		scenarioName string
+		new          func()
+	}{
+		{scenarioName: "mata pelajaran", new: func() { service.NewMata_PelajaranService(db) }},
+		{scenarioName: "kelas", new: func() { service.NewKelasService(db) }},
+		{scenarioName: "wali kelas", new: func() { service.NewWali_KelasService(db) }},
+		{scenarioName: "siswa", new: func() { service.NewSiswaService(db) }},
+		{scenarioName: "user", new: func() { service.NewUserService(db) }},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("expect no panic, but got %v", r)
+				}
+			}()
+			v.new()
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package dbtest opens PostgreSQL for one test. Every statement runs in one transaction that is
+// rolled back when database is closed, so tests leave nothing behind and do not see rows of
+// each other. Tests can then run alone, in any order, or in parallel.
+//
+// Unique indexes are shared though: a test that inserts a value another running test has inserted
+// waits until that test ends, and two tests that do it in different order deadlock. So give every
+// test its own unique values, such as a prefix in kode.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// savepointName is name of savepoint of a transaction. database/sql runs one transaction at
+// a time on a connection, so savepoints are never nested.
+const savepointName = "dbtest_tx"
+
+// Open connects to dsn and begins test transaction. Transactions begun on returned database are
+// savepoints of test transaction: commit releases savepoint, rollback reverts to it. Their
+// isolation options are ignored. Close rolls back everything.
+func Open(dsn string) (*sqlx.DB, error) {
+	c := &connector{dsn: dsn}
+	db := sql.OpenDB(c)
+
+	// one connection holds test transaction for whole life of database
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	err := db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "open: connect to test database failed")
+	}
+
+	return sqlx.NewDb(db, "postgres"), nil
+}
+
+type connector struct {
+	dsn string
+
+	mu        sync.Mutex
+	connected bool
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// a new connection would not see what test wrote so far
+	if c.connected {
+		return nil, errors.New("connect: connection of test transaction is lost")
+	}
+
+	base, err := pq.Driver{}.Open(c.dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "connect: connect to database failed")
+	}
+
+	cn := &conn{base: base}
+	err = cn.exec(ctx, "BEGIN")
+	if err != nil {
+		base.Close()
+		return nil, errors.Wrap(err, "connect: begin test transaction failed")
+	}
+	c.connected = true
+
+	return cn, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return pq.Driver{}
+}
+
+// conn is pq connection inside test transaction
+type conn struct {
+	base driver.Conn
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.base.Prepare(query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if base, ok := c.base.(driver.ConnPrepareContext); ok {
+		return base.PrepareContext(ctx, query)
+	}
+
+	return c.base.Prepare(query)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.base.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.base.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if base, ok := c.base.(driver.Pinger); ok {
+		return base.Ping(ctx)
+	}
+
+	return c.exec(ctx, "SELECT 1")
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	err := c.exec(ctx, "SAVEPOINT "+savepointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &savepoint{conn: c}, nil
+}
+
+// Close rolls back test transaction
+func (c *conn) Close() error {
+	err := c.exec(context.Background(), "ROLLBACK")
+	closeErr := c.base.Close()
+	if err != nil {
+		return errors.Wrap(err, "close: rollback test transaction failed")
+	}
+
+	return closeErr
+}
+
+func (c *conn) exec(ctx context.Context, statement string) error {
+	_, err := c.ExecContext(ctx, statement, nil)
+	return err
+}
+
+// savepoint is a transaction begun inside test transaction
+type savepoint struct {
+	conn *conn
+}
+
+func (s *savepoint) Commit() error {
+	return s.conn.exec(context.Background(), "RELEASE SAVEPOINT "+savepointName)
+}
+
+func (s *savepoint) Rollback() error {
+	err := s.conn.exec(context.Background(), "ROLLBACK TO SAVEPOINT "+savepointName)
+	if err != nil {
+		return err
+	}
+
+	return s.conn.exec(context.Background(), "RELEASE SAVEPOINT "+savepointName)
+}
@@ -0,0 +1,124 @@
+// Package dbtx runs units of work in one database transaction. A service method wraps its queries
+// in WithTx. When it is called inside WithTx of another method, it joins that transaction instead of
+// beginning its own, so operations across services commit or roll back together.
+//
+// Usage:
+//
+//	err := dbtx.WithTx(ctx, db, func(ctx context.Context, tx *sqlx.Tx) error {
+//		siswa, err := siswaService.CreateSiswa(ctx, request)
+//		if err != nil {
+//			return err
+//		}
+//		_, err = tx.ExecContext(ctx, "UPDATE public.users SET ... WHERE id=$1", siswa.ID)
+//		return err
+//	})
+package dbtx
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+// MaxAttempts is how many times WithTx runs unit of work that fails to serialize
+const MaxAttempts = 3
+
+// retryDelay is base delay before retry, grown with every attempt and jittered
+var retryDelay = 20 * time.Millisecond
+
+type txKey struct{}
+
+// WithTx runs fn in transaction. Context passed to fn carries the transaction, so WithTx called
+// with it joins the transaction. Outermost WithTx begins transaction on db, commits it when fn
+// returns nil, and rolls it back when fn returns error or panics. Panic is raised again after
+// rollback. Error of fn is returned unchanged.
+//
+// Outermost WithTx runs fn again, up to MaxAttempts, when transaction fails with serialization
+// failure or deadlock, so fn must not have effects outside of tx.
+func WithTx(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context, tx *sqlx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx, tx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		err = run(ctx, db, fn)
+		if err == nil || !Retryable(err) || attempt == MaxAttempts {
+			return err
+		}
+
+		delay := time.Duration(attempt) * retryDelay
+		delay += time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+
+	return err
+}
+
+// Tx returns transaction of WithTx that ctx carries
+func Tx(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// run runs fn once in new transaction
+func run(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context, tx *sqlx.Tx) error) (err error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "withtx: begin transaction failed")
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		tx.Rollback()
+		if p := recover(); p != nil {
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx), tx)
+	if err != nil {
+		return err
+	}
+
+	committed = true
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "withtx: commit transaction failed")
+	}
+
+	return nil
+}
+
+// Retryable returns true when err, or error that API error wraps, is serialization failure or
+// deadlock, which succeed when transaction is run again
+func Retryable(err error) bool {
+	if ae, ok := err.(*apierror.APIError); ok {
+		err = ae.Err
+	}
+
+	pqErr, ok := errors.Cause(err).(*pq.Error)
+	if !ok {
+		return false
+	}
+
+	switch pqErr.Code {
+	case "40001", "40P01":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,289 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+// recorder is database of fake driver. It records transaction events and fails commits in order.
+type recorder struct {
+	mu         sync.Mutex
+	events     []string
+	commitErrs []error
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = map[string]*recorder{}
+)
+
+type fakeDriver struct{}
+
+type fakeConn struct{ r *recorder }
+
+type fakeTx struct{ r *recorder }
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	return &fakeConn{r: recorders[name]}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fake: prepare is not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.r.record("begin")
+	return &fakeTx{r: c.r}, nil
+}
+
+func (t *fakeTx) Commit() error {
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	t.r.events = append(t.r.events, "commit")
+	if len(t.r.commitErrs) > 0 {
+		err := t.r.commitErrs[0]
+		t.r.commitErrs = t.r.commitErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.r.record("rollback")
+	return nil
+}
+
+func init() {
+	sql.Register("dbtxtest", fakeDriver{})
+	retryDelay = time.Millisecond
+}
+
+// newTestDB returns database of fake driver that fails commits with commitErrs
+func newTestDB(t *testing.T, commitErrs ...error) (*sqlx.DB, *recorder) {
+	r := &recorder{commitErrs: commitErrs}
+	name := t.Name()
+
+	recordersMu.Lock()
+	recorders[name] = r
+	recordersMu.Unlock()
+
+	db, err := sqlx.Open("dbtxtest", name)
+	if err != nil {
+		t.Fatalf("open fake database failed: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db, r
+}
+
+// serializationFailure is error that services return when query fails to serialize
+func serializationFailure() error {
+	return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", pkgerrors.Wrap(&pq.Error{Code: "40001"}, "updatesiswa: update data failed"))
+}
+
+func TestWithTx(t *testing.T) {
+	errFailed := apierror.NewError(http.StatusBadRequest, apierror.RequestInvalid, "Siswa data invalid", pkgerrors.New("createsiswa: invalid"))
+
+	testScenarios := []struct {
+		scenarioName     string
+		commitErrs       []error
+		failures         []error
+		expectedError    bool
+		expectedErr      error
+		expectedAttempts int
+		expectedEvents   []string
+	}{
+		{
+			scenarioName:     "commit when unit of work succeeds",
+			expectedAttempts: 1,
+			expectedEvents:   []string{"begin", "commit"},
+		},
+		{
+			scenarioName:     "rollback and return error of unit of work",
+			failures:         []error{errFailed},
+			expectedError:    true,
+			expectedErr:      errFailed,
+			expectedAttempts: 1,
+			expectedEvents:   []string{"begin", "rollback"},
+		},
+		{
+			scenarioName:     "retry serialization failure",
+			failures:         []error{serializationFailure(), serializationFailure()},
+			expectedAttempts: 3,
+			expectedEvents:   []string{"begin", "rollback", "begin", "rollback", "begin", "commit"},
+		},
+		{
+			scenarioName:     "retry deadlock",
+			failures:         []error{&pq.Error{Code: "40P01"}},
+			expectedAttempts: 2,
+			expectedEvents:   []string{"begin", "rollback", "begin", "commit"},
+		},
+		{
+			scenarioName:     "retry serialization failure on commit",
+			commitErrs:       []error{&pq.Error{Code: "40001"}},
+			expectedAttempts: 2,
+			expectedEvents:   []string{"begin", "commit", "begin", "commit"},
+		},
+		{
+			scenarioName:     "give up after max attempts",
+			failures:         []error{serializationFailure(), serializationFailure(), serializationFailure()},
+			expectedError:    true,
+			expectedAttempts: MaxAttempts,
+			expectedEvents:   []string{"begin", "rollback", "begin", "rollback", "begin", "rollback"},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			db, r := newTestDB(t, v.commitErrs...)
+
+			attempts := 0
+			err := WithTx(context.Background(), db, func(ctx context.Context, tx *sqlx.Tx) error {
+				attempts++
+				if attempts <= len(v.failures) {
+					return v.failures[attempts-1]
+				}
+				return nil
+			})
+
+			if (err != nil) != v.expectedError {
+				t.Errorf("expect error %t, but got %v", v.expectedError, err)
+				return
+			}
+			if v.expectedErr != nil && err != v.expectedErr {
+				t.Errorf("expect error %v, but got %v", v.expectedErr, err)
+				return
+			}
+
+			if attempts != v.expectedAttempts {
+				t.Errorf("expect %d attempts, but got %d", v.expectedAttempts, attempts)
+				return
+			}
+
+			if !reflect.DeepEqual(r.events, v.expectedEvents) {
+				t.Errorf("expect events %v, but got %v", v.expectedEvents, r.events)
+				return
+			}
+		})
+	}
+}
+
+func TestWithTx_Panic(t *testing.T) {
+	db, r := newTestDB(t)
+
+	recovered := func() (recovered interface{}) {
+		defer func() { recovered = recover() }()
+		WithTx(context.Background(), db, func(ctx context.Context, tx *sqlx.Tx) error {
+			panic("siswa without kelas")
+		})
+		return nil
+	}()
+
+	if recovered != "siswa without kelas" {
+		t.Errorf("expect panic to be raised again, but got %v", recovered)
+		return
+	}
+
+	expectedEvents := []string{"begin", "rollback"}
+	if !reflect.DeepEqual(r.events, expectedEvents) {
+		t.Errorf("expect events %v, but got %v", expectedEvents, r.events)
+		return
+	}
+}
+
+func TestWithTx_Join(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName   string
+		innerErr       error
+		expectedEvents []string
+	}{
+		{
+			scenarioName:   "inner unit of work commits with outer",
+			expectedEvents: []string{"begin", "commit"},
+		},
+		{
+			scenarioName:   "error of inner unit of work rolls back outer",
+			innerErr:       errors.New("link parent failed"),
+			expectedEvents: []string{"begin", "rollback"},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			db, r := newTestDB(t)
+
+			var outerTx, innerTx *sqlx.Tx
+			err := WithTx(context.Background(), db, func(ctx context.Context, tx *sqlx.Tx) error {
+				outerTx = tx
+				return WithTx(ctx, db, func(ctx context.Context, tx *sqlx.Tx) error {
+					innerTx = tx
+					if joined, ok := Tx(ctx); !ok || joined != tx {
+						return errors.New("context does not carry transaction")
+					}
+					return v.innerErr
+				})
+			})
+
+			if err != v.innerErr {
+				t.Errorf("expect error %v, but got %v", v.innerErr, err)
+				return
+			}
+			if innerTx != outerTx {
+				t.Errorf("expect inner unit of work to join transaction of outer")
+				return
+			}
+			if !reflect.DeepEqual(r.events, v.expectedEvents) {
+				t.Errorf("expect events %v, but got %v", v.expectedEvents, r.events)
+				return
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		err          error
+		expected     bool
+	}{
+		{scenarioName: "serialization failure", err: &pq.Error{Code: "40001"}, expected: true},
+		{scenarioName: "deadlock", err: &pq.Error{Code: "40P01"}, expected: true},
+		{scenarioName: "unique violation", err: &pq.Error{Code: "23505"}, expected: false},
+		{scenarioName: "serialization failure in API error", err: serializationFailure(), expected: true},
+		{scenarioName: "wrapped deadlock", err: pkgerrors.Wrap(&pq.Error{Code: "40P01"}, "withtx: commit transaction failed"), expected: true},
+		{scenarioName: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			retryable := Retryable(v.err)
+			if retryable != v.expected {
+				t.Errorf("expect retryable %t, but got %t", v.expected, retryable)
+				return
+			}
+		})
+	}
+}
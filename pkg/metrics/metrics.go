@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+// collectTimeout keeps slow database from blocking the scrape
+const collectTimeout = 5 * time.Second
+
+// AttendanceCounter counts attendance records written today and in active semester. Count of
+// semester is false when no semester is active.
+type AttendanceCounter interface {
+	CountAttendanceToday(ctx context.Context) (int, error)
+	CountAttendanceSemester(ctx context.Context) (int, bool, error)
+}
+
+// BusinessCollector exports business gauges that are computed from database on every scrape
+type BusinessCollector struct {
+	attendance             AttendanceCounter
+	logger                 *zap.Logger
+	attendanceDesc         *prometheus.Desc
+	attendanceSemesterDesc *prometheus.Desc
+}
+
+// NewBusinessCollector ...
+func NewBusinessCollector(namespace string, attendance AttendanceCounter, logger *zap.Logger) *BusinessCollector {
+	return &BusinessCollector{
+		attendance: attendance,
+		logger:     logger,
+		attendanceDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "attendance_records_today"),
+			"Attendance records written since start of today.",
+			nil, nil,
+		),
+		attendanceSemesterDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "attendance_records_semester"),
+			"Attendance records written in active semester.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *BusinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.attendanceDesc
+	ch <- c.attendanceSemesterDesc
+}
+
+// Collect implements prometheus.Collector. Gauge that fails to compute is left out of the scrape,
+// so is gauge of semester when no semester is active.
+func (c *BusinessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	total, err := c.attendance.CountAttendanceToday(ctx)
+	if err != nil {
+		c.logError("Failed to collect attendance metric", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.attendanceDesc, prometheus.GaugeValue, float64(total))
+	}
+
+	total, active, err := c.attendance.CountAttendanceSemester(ctx)
+	if err != nil {
+		c.logError("Failed to collect attendance of semester metric", err)
+	} else if active {
+		ch <- prometheus.MustNewConstMetric(c.attendanceSemesterDesc, prometheus.GaugeValue, float64(total))
+	}
+}
+
+// logError logs err of gauge, with cause of application error
+func (c *BusinessCollector) logError(msg string, err error) {
+	if ae, ok := err.(*apierror.APIError); ok {
+		err = ae.Err
+	}
+	c.logger.Error(msg, zap.Error(err))
+}
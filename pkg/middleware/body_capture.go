@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// redactedValue replaces sensitive value in logged body and header
+const redactedValue = "[REDACTED]"
+
+var (
+	// DefaultRedactFields are JSON fields that never appear in logs.
+	// Our data covers minors and their families, so keep this list on the safe side. Nama is
+	// name of siswa or wali kelas; nama of kelas and mata pelajaran is redacted with it.
+	DefaultRedactFields = []string{"password", "telepon", "telpon", "alamat", "nama"}
+
+	// DefaultRedactHeaders are request headers that never appear in logs
+	DefaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+)
+
+// limitedBuffer keeps at most limit bytes and remembers whether more was written
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	room := b.limit - b.buf.Len()
+	if len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// captureResponseWriter copies response body into a limitedBuffer
+type captureResponseWriter struct {
+	http.ResponseWriter
+	body *limitedBuffer
+}
+
+func (w *captureResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// captureRequestBody reads up to limit bytes of request body and puts them back
+// so handler still sees the whole body
+func captureRequestBody(req *http.Request, limit int) *limitedBuffer {
+	body := &limitedBuffer{limit: limit}
+	if req.Body == nil {
+		return body
+	}
+
+	head, _ := ioutil.ReadAll(io.LimitReader(req.Body, int64(limit)+1))
+	body.Write(head)
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), req.Body), req.Body}
+
+	return body
+}
+
+// formatBody returns redacted JSON body. Bodies that are truncated or not JSON are not logged
+// at all because we can't guarantee their sensitive fields are redacted.
+func formatBody(body *limitedBuffer, redactFields map[string]bool) string {
+	if body.buf.Len() == 0 {
+		return ""
+	}
+
+	if body.truncated {
+		return "[NOT LOGGED: body exceeds " + strconv.Itoa(body.limit) + " bytes]"
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body.buf.Bytes(), &v); err != nil {
+		return "[NOT LOGGED: body is not JSON]"
+	}
+
+	b, err := json.Marshal(redact(v, redactFields))
+	if err != nil {
+		return "[NOT LOGGED: body is not JSON]"
+	}
+
+	return string(b)
+}
+
+// redact replaces value of every field in redactFields, at any depth
+func redact(v interface{}, redactFields map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if redactFields[strings.ToLower(k)] {
+				t[k] = redactedValue
+				continue
+			}
+			t[k] = redact(fv, redactFields)
+		}
+	case []interface{}:
+		for i, iv := range t {
+			t[i] = redact(iv, redactFields)
+		}
+	}
+	return v
+}
+
+// formatHeader flattens header and redacts sensitive ones
+func formatHeader(header http.Header, redactHeaders map[string]bool) map[string]string {
+	h := make(map[string]string, len(header))
+	for k, v := range header {
+		if redactHeaders[http.CanonicalHeaderKey(k)] {
+			h[k] = redactedValue
+			continue
+		}
+		h[k] = strings.Join(v, ", ")
+	}
+	return h
+}
+
+// lookupSet builds case normalized lookup table
+func lookupSet(values []string, normalize func(string) string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[normalize(v)] = true
+	}
+	return set
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/logging"
 	"github.com/syukur91/ischool-monitor/pkg/response"
 )
 
@@ -26,7 +27,7 @@ func ErrorHandler(logger *zap.Logger) func(error, echo.Context) {
 
 		ae, ok := err.(*apierror.APIError)
 		if !ok {
-			err = apierror.NewError(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), errors.Wrap(err, "errorhandler: internal error"))
+			err = apierror.NewError(http.StatusInternalServerError, apierror.InternalError, http.StatusText(http.StatusInternalServerError), errors.Wrap(err, "errorhandler: internal error"))
 			ae, _ = err.(*apierror.APIError)
 		}
 
@@ -47,15 +48,20 @@ func ErrorHandler(logger *zap.Logger) func(error, echo.Context) {
 			path = "/"
 		}
 
+		logger := logging.FromContext(req.Context(), logger)
 		logger.Error(ae.Message,
 			zap.String("path", path),
 			zap.String("tenant", tenant),
 			zap.String("method", req.Method),
+			zap.String("code", string(ae.Code)),
 			zap.Error(ae.Err))
 
 		r := new(response.Response)
 		es := make([]response.Error, 1)
-		es[0] = response.Error{Status: ae.HTTPStatus, Code: ae.Code, Title: http.StatusText(ae.Code), Detail: ae.Message}
+		es[0] = response.Error{Status: ae.HTTPStatus, Code: string(ae.Code), Title: apierror.StatusText(ae.HTTPStatus), Detail: ae.Message}
+		if rid := logging.RequestID(req.Context()); rid != "" {
+			es[0].Meta = map[string]interface{}{"request_id": rid}
+		}
 		r.Errors = es
 
 		// Send response
@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
 	"github.com/syukur91/ischool-monitor/pkg/query"
 )
 
@@ -12,7 +17,7 @@ func KendoGrid(next echo.HandlerFunc) echo.HandlerFunc {
 		gridParams := new(query.GridParams)
 		err := c.Bind(gridParams)
 		if err != nil {
-			return err
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.RequestBindFailed, "Failed to get grid parameters. Probably content-type is not match with actual body type", errors.Wrap(err, "kendogrid: bind grid parameters failed"))
 		}
 
 		if len(gridParams.Sort) > 0 {
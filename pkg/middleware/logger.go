@@ -1,12 +1,16 @@
 package middleware
 
 import (
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/logging"
 )
 
 type (
@@ -15,12 +19,52 @@ type (
 		Skipper Skipper
 		Logger  *zap.Logger
 		AppName string
+
+		// LogBody enables request and response body capture. Only JSON bodies are logged,
+		// with RedactFields replaced. Optional. Default false.
+		LogBody bool
+
+		// LogHeader enables request header capture, with RedactHeaders replaced.
+		// Optional. Default false.
+		LogHeader bool
+
+		// MaxBodySize is the largest body in bytes that is logged. Larger bodies are not logged.
+		// Optional. Default 4096.
+		MaxBodySize int
+
+		// BodySkipper skips body and header capture for specific routes, e.g. by c.Path().
+		// Optional. Default never skip.
+		BodySkipper Skipper
+
+		// RedactFields are JSON fields, at any depth, whose values are never logged.
+		// Optional. Default DefaultRedactFields.
+		RedactFields []string
+
+		// RedactHeaders are request headers whose values are never logged.
+		// Optional. Default DefaultRedactHeaders.
+		RedactHeaders []string
 	}
 )
 
+const defaultMaxBodySize = 4096
+
 // LoggerWithConfig returns a Logger middleware with config.
 // See: `Logger()`.
 func LoggerWithConfig(config LoggerConfig) echo.MiddlewareFunc {
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = defaultMaxBodySize
+	}
+	if config.BodySkipper == nil {
+		config.BodySkipper = defaultSkipper
+	}
+	if config.RedactFields == nil {
+		config.RedactFields = DefaultRedactFields
+	}
+	if config.RedactHeaders == nil {
+		config.RedactHeaders = DefaultRedactHeaders
+	}
+	redactFields := lookupSet(config.RedactFields, strings.ToLower)
+	redactHeaders := lookupSet(config.RedactHeaders, http.CanonicalHeaderKey)
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) (err error) {
@@ -30,6 +74,15 @@ func LoggerWithConfig(config LoggerConfig) echo.MiddlewareFunc {
 
 			req := c.Request()
 			res := c.Response()
+
+			captureBody := config.LogBody && !config.BodySkipper(c)
+			var reqBody, resBody *limitedBuffer
+			if captureBody {
+				reqBody = captureRequestBody(req, config.MaxBodySize)
+				resBody = &limitedBuffer{limit: config.MaxBodySize}
+				res.Writer = &captureResponseWriter{ResponseWriter: res.Writer, body: resBody}
+			}
+
 			start := time.Now()
 			if err = next(c); err != nil {
 				c.Error(err)
@@ -53,7 +106,8 @@ func LoggerWithConfig(config LoggerConfig) echo.MiddlewareFunc {
 				bytesIn = "0"
 			}
 
-			config.Logger.Info("Request handled",
+			logger := logging.FromContext(req.Context(), config.Logger)
+			fields := []zap.Field{
 				zap.String("tenant", tenant),
 				zap.String("type", "request"),
 				zap.String("remote_ip", c.RealIP()),
@@ -68,9 +122,20 @@ func LoggerWithConfig(config LoggerConfig) echo.MiddlewareFunc {
 				zap.String("latency_human", latencyHuman),
 				zap.String("bytes_in", bytesIn),
 				zap.String("bytes_out", strconv.FormatInt(res.Size, 10)),
-			)
+			}
+
+			if config.LogHeader && !config.BodySkipper(c) {
+				fields = append(fields, zap.Any("header", formatHeader(req.Header, redactHeaders)))
+			}
+
+			if captureBody {
+				fields = append(fields,
+					zap.String("body_in", formatBody(reqBody, redactFields)),
+					zap.String("body_out", formatBody(resBody, redactFields)),
+				)
+			}
 
-			// Todo: add body and header to log
+			logger.Info("Request handled", fields...)
 
 			return nil
 		}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggerBodyRedaction(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName    string
+		path            string
+		requestBody     string
+		maxBodySize     int
+		expectedBodyIn  string
+		expectedBodyOut string
+		expectedHeader  bool
+	}{
+		{
+			scenarioName:    "redact sensitive fields",
+			path:            "/users",
+			requestBody:     `{"nama":"Cendana","alamat":"Jalan Cendana","password":"secret","anak":[{"telepon":"0291"}]}`,
+			expectedBodyIn:  `{"alamat":"[REDACTED]","anak":[{"telepon":"[REDACTED]"}],"nama":"[REDACTED]","password":"[REDACTED]"}`,
+			expectedBodyOut: `{"alamat":"[REDACTED]","anak":[{"telepon":"[REDACTED]"}],"nama":"[REDACTED]","password":"[REDACTED]"}`,
+			expectedHeader:  true,
+		},
+		{
+			scenarioName:    "don't log body that is not JSON",
+			path:            "/users",
+			requestBody:     `nama=Cendana&password=secret`,
+			expectedBodyIn:  "[NOT LOGGED: body is not JSON]",
+			expectedBodyOut: "[NOT LOGGED: body is not JSON]",
+			expectedHeader:  true,
+		},
+		{
+			scenarioName:    "don't log body that exceeds limit",
+			path:            "/users",
+			requestBody:     `{"nama":"Cendana","password":"secret"}`,
+			maxBodySize:     10,
+			expectedBodyIn:  "[NOT LOGGED: body exceeds 10 bytes]",
+			expectedBodyOut: "[NOT LOGGED: body exceeds 10 bytes]",
+			expectedHeader:  true,
+		},
+		{
+			scenarioName: "skip route",
+			path:         "/users-grid",
+			requestBody:  `{"nama":"Cendana"}`,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+
+			e := echo.New()
+			e.Use(LoggerWithConfig(LoggerConfig{
+				Skipper:     defaultSkipper,
+				Logger:      zap.New(core),
+				LogBody:     true,
+				LogHeader:   true,
+				MaxBodySize: v.maxBodySize,
+				BodySkipper: func(c echo.Context) bool {
+					return strings.HasSuffix(c.Path(), "-grid")
+				},
+			}))
+			echoBody := func(c echo.Context) error {
+				body, err := ioutil.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+				return c.String(http.StatusOK, string(body))
+			}
+			e.POST("/users", echoBody)
+			e.POST("/users-grid", echoBody)
+
+			req := httptest.NewRequest(http.MethodPost, v.path, strings.NewReader(v.requestBody))
+			req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Body.String() != v.requestBody {
+				t.Errorf("expect handler to get full body %s, but got %s", v.requestBody, rec.Body.String())
+				return
+			}
+
+			if logs.Len() != 1 {
+				t.Errorf("expect 1 log entry, but got %d", logs.Len())
+				return
+			}
+			fields := logs.All()[0].ContextMap()
+
+			if bodyIn, _ := fields["body_in"].(string); bodyIn != v.expectedBodyIn {
+				t.Errorf("expect body_in %s, but got %s", v.expectedBodyIn, bodyIn)
+				return
+			}
+
+			if bodyOut, _ := fields["body_out"].(string); bodyOut != v.expectedBodyOut {
+				t.Errorf("expect body_out %s, but got %s", v.expectedBodyOut, bodyOut)
+				return
+			}
+
+			header, hasHeader := fields["header"].(map[string]string)
+			if hasHeader != v.expectedHeader {
+				t.Errorf("expect header logged %t, but got %t", v.expectedHeader, hasHeader)
+				return
+			}
+
+			if hasHeader && header[echo.HeaderAuthorization] != redactedValue {
+				t.Errorf("expect authorization header %s, but got %s", redactedValue, header[echo.HeaderAuthorization])
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// MetricsConfig defines the config for Metrics middleware.
+	MetricsConfig struct {
+		Skipper Skipper
+
+		// Registerer registers request metrics.
+		// Optional. Default prometheus.DefaultRegisterer.
+		Registerer prometheus.Registerer
+
+		// Namespace prefixes every metric name.
+		// Optional. Default "ischool".
+		Namespace string
+
+		// ActiveTenantWindow is how long a tenant is counted as active after its last request.
+		// Optional. Default 24 hours.
+		ActiveTenantWindow time.Duration
+
+		// Tenants are tenants that label metrics by their name. Tenant comes from path, which
+		// anyone can send, so other tenants share label OtherTenant.
+		// Optional. Default the first MaxTenants tenants that make a request.
+		Tenants []string
+
+		// MaxTenants is how many tenants label metrics by their name when Tenants is not set.
+		// Optional. Default 100.
+		MaxTenants int
+	}
+)
+
+// OtherTenant labels metrics of tenants that are not labelled by their name
+const OtherTenant = "other"
+
+// MetricsWithConfig returns a middleware that records request latency histogram
+// labelled by route, method, status and tenant, and active tenants gauge.
+func MetricsWithConfig(config MetricsConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+	if config.Registerer == nil {
+		config.Registerer = prometheus.DefaultRegisterer
+	}
+	if config.Namespace == "" {
+		config.Namespace = "ischool"
+	}
+	if config.ActiveTenantWindow <= 0 {
+		config.ActiveTenantWindow = 24 * time.Hour
+	}
+	if config.MaxTenants <= 0 {
+		config.MaxTenants = 100
+	}
+
+	requestDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: config.Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route, method, status and tenant.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status", "tenant"})
+
+	tenants := &tenantTracker{
+		window:   config.ActiveTenantWindow,
+		max:      config.MaxTenants,
+		labelled: map[string]bool{},
+		lastSeen: map[string]time.Time{},
+	}
+	if config.Tenants != nil {
+		tenants.allowed = lookupSet(config.Tenants, func(s string) string { return s })
+	}
+	activeTenants := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: config.Namespace,
+		Name:      "active_tenants",
+		Help:      "Tenants that made a request within the active window. Other tenants count as one.",
+	}, tenants.count)
+
+	config.Registerer.MustRegister(requestDuration, activeTenants)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			start := time.Now()
+			if err = next(c); err != nil {
+				c.Error(err)
+			}
+			stop := time.Now()
+
+			// Use route pattern instead of actual path to keep label cardinality low.
+			// Unmatched route can have any tenant, so don't label it
+			route := c.Path()
+			tenant := c.Param("tenant")
+			if route == "" || tenant == "" {
+				route = "unmatched"
+				tenant = "none"
+			} else {
+				tenant = tenants.seen(tenant, stop)
+			}
+
+			requestDuration.WithLabelValues(
+				route,
+				c.Request().Method,
+				strconv.Itoa(c.Response().Status),
+				tenant,
+			).Observe(stop.Sub(start).Seconds())
+
+			return nil
+		}
+	}
+}
+
+// tenantTracker remembers when each tenant made its last request, and which tenants label
+// metrics by their name. Both are bounded by allowed tenants, or by max, plus OtherTenant.
+type tenantTracker struct {
+	mu       sync.Mutex
+	window   time.Duration
+	max      int
+	allowed  map[string]bool
+	labelled map[string]bool
+	lastSeen map[string]time.Time
+}
+
+// seen records request of tenant at time and returns label of tenant. Tenant that is not allowed,
+// or that comes after max tenants, is labelled OtherTenant.
+func (t *tenantTracker) seen(tenant string, at time.Time) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.labelled[tenant] {
+		labelled := len(t.labelled) < t.max
+		if t.allowed != nil {
+			labelled = t.allowed[tenant]
+		}
+		if !labelled {
+			tenant = OtherTenant
+		} else {
+			t.labelled[tenant] = true
+		}
+	}
+
+	t.lastSeen[tenant] = at
+	t.forget(at)
+
+	return tenant
+}
+
+// count returns number of active tenants and forgets the inactive ones
+func (t *tenantTracker) count() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.forget(time.Now())
+
+	return float64(len(t.lastSeen))
+}
+
+// forget deletes tenants that made no request within window before now. Caller holds lock.
+func (t *tenantTracker) forget(now time.Time) {
+	since := now.Add(-t.window)
+	for tenant, at := range t.lastSeen {
+		if at.Before(since) {
+			delete(t.lastSeen, tenant)
+		}
+	}
+}
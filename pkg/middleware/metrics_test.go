@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	e := echo.New()
+	e.Use(MetricsWithConfig(MetricsConfig{Registerer: registry}))
+	e.GET("/:tenant/hello", func(c echo.Context) error {
+		return c.String(http.StatusOK, "hello")
+	})
+
+	for _, path := range []string{"/sekolah-a/hello", "/sekolah-a/hello", "/sekolah-b/hello", "/sekolah-c/unknown"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	expected := `
+# HELP ischool_active_tenants Tenants that made a request within the active window. Other tenants count as one.
+# TYPE ischool_active_tenants gauge
+ischool_active_tenants 2
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "ischool_active_tenants")
+	if err != nil {
+		t.Errorf("unexpected active tenants metric: %s", err)
+		return
+	}
+
+	testScenarios := []struct {
+		scenarioName  string
+		route         string
+		method        string
+		status        string
+		tenant        string
+		expectedCount uint64
+	}{
+		{
+			scenarioName:  "matched route",
+			route:         "/:tenant/hello",
+			method:        "GET",
+			status:        "200",
+			tenant:        "sekolah-a",
+			expectedCount: 2,
+		},
+		{
+			scenarioName:  "unmatched route is not labelled by tenant",
+			route:         "unmatched",
+			method:        "GET",
+			status:        "404",
+			tenant:        "none",
+			expectedCount: 1,
+		},
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			var count uint64
+			for _, f := range families {
+				if f.GetName() != "ischool_http_request_duration_seconds" {
+					continue
+				}
+				for _, m := range f.GetMetric() {
+					labels := map[string]string{}
+					for _, l := range m.GetLabel() {
+						labels[l.GetName()] = l.GetValue()
+					}
+					if labels["route"] == v.route && labels["method"] == v.method && labels["status"] == v.status && labels["tenant"] == v.tenant {
+						count = m.GetHistogram().GetSampleCount()
+					}
+				}
+			}
+
+			if count != v.expectedCount {
+				t.Errorf("expect count %d, but got %d", v.expectedCount, count)
+				return
+			}
+		})
+	}
+}
+
+// TestMetrics_OtherTenant checks that tenants of path don't add label values without limit
+func TestMetrics_OtherTenant(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName   string
+		config         MetricsConfig
+		expectedLabels []string
+		expectedActive string
+	}{
+		{
+			scenarioName:   "first max tenants are labelled",
+			config:         MetricsConfig{MaxTenants: 2},
+			expectedLabels: []string{"other", "sekolah-a", "sekolah-b"},
+			expectedActive: "3",
+		},
+		{
+			scenarioName:   "allowed tenants are labelled",
+			config:         MetricsConfig{Tenants: []string{"sekolah-c"}},
+			expectedLabels: []string{"other", "sekolah-c"},
+			expectedActive: "2",
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			v.config.Registerer = registry
+
+			e := echo.New()
+			e.Use(MetricsWithConfig(v.config))
+			e.GET("/:tenant/hello", func(c echo.Context) error {
+				return c.String(http.StatusOK, "hello")
+			})
+
+			for _, path := range []string{"/sekolah-a/hello", "/sekolah-b/hello", "/sekolah-c/hello", "/sekolah-d/hello"} {
+				e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+			}
+
+			expected := `
+# HELP ischool_active_tenants Tenants that made a request within the active window. Other tenants count as one.
+# TYPE ischool_active_tenants gauge
+ischool_active_tenants ` + v.expectedActive + `
+`
+			err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "ischool_active_tenants")
+			if err != nil {
+				t.Errorf("unexpected active tenants metric: %s", err)
+				return
+			}
+
+			families, err := registry.Gather()
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			labels := []string{}
+			for _, f := range families {
+				if f.GetName() != "ischool_http_request_duration_seconds" {
+					continue
+				}
+				for _, m := range f.GetMetric() {
+					for _, l := range m.GetLabel() {
+						if l.GetName() == "tenant" {
+							labels = append(labels, l.GetValue())
+						}
+					}
+				}
+			}
+			sort.Strings(labels)
+
+			if !reflect.DeepEqual(labels, v.expectedLabels) {
+				t.Errorf("expect tenant labels %v, but got %v", v.expectedLabels, labels)
+				return
+			}
+		})
+	}
+}
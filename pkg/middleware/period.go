@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/period"
+)
+
+type (
+	// PeriodConfig defines the config for Period middleware.
+	PeriodConfig struct {
+		Skipper Skipper
+
+		// Resolver looks up semester of period parameter, or active semester.
+		// Required.
+		Resolver period.Resolver
+	}
+)
+
+// PeriodWithConfig returns a middleware that puts semester parameter of request into request
+// context, where scoped grids resolve it to their period, see package period
+func PeriodWithConfig(config PeriodConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+	if config.Resolver == nil {
+		panic("echo: period middleware requires resolver")
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := period.WithSemester(req.Context(), config.Resolver, c.QueryParam(period.Param))
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
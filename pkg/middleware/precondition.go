@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/precondition"
+)
+
+type (
+	// PreconditionConfig defines the config for Precondition middleware.
+	PreconditionConfig struct {
+		Skipper Skipper
+
+		// RequireIfMatch makes update and delete fail with 428 when request has no If-Match.
+		// Optional. Default false, If-Match is only honored when it is sent.
+		RequireIfMatch bool
+	}
+)
+
+// PreconditionWithConfig returns a middleware that puts If-Match of PUT, PATCH and DELETE into
+// request context, where services compare it with the row they change, see package precondition
+func PreconditionWithConfig(config PreconditionConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			switch req.Method {
+			case http.MethodPut, http.MethodPatch, http.MethodDelete:
+				ctx := precondition.WithIfMatch(req.Context(), req.Header.Get(precondition.HeaderIfMatch), config.RequireIfMatch)
+				c.SetRequest(req.WithContext(ctx))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	"github.com/labstack/gommon/random"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/logging"
+)
+
+// maxRequestIDLength limits client supplied X-Request-ID so it can't flood the logs
+const maxRequestIDLength = 64
+
+type (
+	// RequestIDConfig defines the config for RequestID middleware.
+	RequestIDConfig struct {
+		Skipper Skipper
+
+		// Logger is base of request-scoped logger.
+		// Optional. Default value zap.NewNop().
+		Logger *zap.Logger
+
+		// Generator generates request id when client doesn't send a valid one.
+		// Optional. Default value random.String(32).
+		Generator func() string
+	}
+)
+
+// RequestIDWithConfig returns a middleware that accepts or generates X-Request-ID,
+// echoes it in response header and stores it with a request-scoped logger in request context.
+// Register it before LoggerWithConfig so request log and error log share the same id.
+func RequestIDWithConfig(config RequestIDConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.Generator == nil {
+		config.Generator = func() string {
+			return random.String(32)
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			rid := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(rid) {
+				rid = config.Generator()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, rid)
+
+			ctx := logging.WithRequestID(req.Context(), rid)
+			ctx = logging.WithLogger(ctx, config.Logger.With(zap.String("request_id", rid)))
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// validRequestID only accepts short printable ids
+func validRequestID(rid string) bool {
+	if rid == "" || len(rid) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range rid {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
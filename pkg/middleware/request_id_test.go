@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName      string
+		headerRequestID   string
+		expectedRequestID string
+	}{
+		{
+			scenarioName:      "accept client request id",
+			headerRequestID:   "ticket-1234",
+			expectedRequestID: "ticket-1234",
+		},
+		{
+			scenarioName:    "generate when request id is not set",
+			headerRequestID: "",
+		},
+		{
+			scenarioName:    "generate when request id is too long",
+			headerRequestID: strings.Repeat("a", maxRequestIDLength+1),
+		},
+		{
+			scenarioName:    "generate when request id has whitespace",
+			headerRequestID: "ticket 1234",
+		},
+	}
+
+	e := echo.New()
+	h := RequestIDWithConfig(RequestIDConfig{
+		Logger:    zap.NewNop(),
+		Generator: func() string { return "generated" },
+	})(func(c echo.Context) error {
+		return c.String(http.StatusOK, logging.RequestID(c.Request().Context()))
+	})
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if v.headerRequestID != "" {
+				req.Header.Set(echo.HeaderXRequestID, v.headerRequestID)
+			}
+			rec := httptest.NewRecorder()
+
+			err := h(e.NewContext(req, rec))
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			expectedRequestID := v.expectedRequestID
+			if expectedRequestID == "" {
+				expectedRequestID = "generated"
+			}
+
+			if rec.Header().Get(echo.HeaderXRequestID) != expectedRequestID {
+				t.Errorf("expect header request id %s, but got %s", expectedRequestID, rec.Header().Get(echo.HeaderXRequestID))
+				return
+			}
+
+			if rec.Body.String() != expectedRequestID {
+				t.Errorf("expect context request id %s, but got %s", expectedRequestID, rec.Body.String())
+				return
+			}
+		})
+	}
+}
+
+func TestRequestID_ZeroConfig(t *testing.T) {
+	e := echo.New()
+	h := RequestIDWithConfig(RequestIDConfig{})(func(c echo.Context) error {
+		logging.FromContext(c.Request().Context(), nil).Info("request")
+		return c.String(http.StatusOK, logging.RequestID(c.Request().Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "ticket-1234")
+	rec := httptest.NewRecorder()
+
+	err := h(e.NewContext(req, rec))
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	if rec.Body.String() != "ticket-1234" {
+		t.Errorf("expect context request id ticket-1234, but got %s", rec.Body.String())
+		return
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+type (
+	// TimeoutConfig defines the config for Timeout middleware.
+	TimeoutConfig struct {
+		Skipper Skipper
+
+		// Timeout returns how long request of route may take.
+		// Optional. Default 30 seconds for every route.
+		Timeout func(c echo.Context) time.Duration
+	}
+)
+
+// DefaultTimeout is timeout of route when TimeoutConfig has no Timeout
+const DefaultTimeout = 30 * time.Second
+
+// TimeoutWithConfig returns a middleware that gives request context a deadline of its route.
+// Services run queries with request context, so queries past deadline, or of a client that
+// disconnected, are canceled in database. Their errors become 504 REQUEST_TIMEOUT and
+// 499 REQUEST_CANCELED. Register it last, so other middleware see the mapped error.
+func TimeoutWithConfig(config TimeoutConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+	if config.Timeout == nil {
+		config.Timeout = func(c echo.Context) time.Duration {
+			return DefaultTimeout
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			timeout := config.Timeout(c)
+			req := c.Request()
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err == nil {
+				return nil
+			}
+
+			// error of canceled query says little, context tells why it was canceled
+			switch ctx.Err() {
+			case context.DeadlineExceeded:
+				return apierror.NewError(http.StatusGatewayTimeout, apierror.RequestTimeout, "Request took longer than "+timeout.String()+". Narrow the filter or try again later", errors.Wrap(err, "timeout: deadline of "+c.Path()+" exceeded"))
+			case context.Canceled:
+				return apierror.NewError(apierror.StatusClientClosedRequest, apierror.RequestCanceled, "Request was canceled by client", errors.Wrap(err, "timeout: request canceled"))
+			}
+
+			return err
+		}
+	}
+}
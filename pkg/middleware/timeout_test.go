@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+)
+
+// slowQuery waits like a query does, until it ends or its context is canceled
+func slowQuery(c echo.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return c.NoContent(http.StatusOK)
+	case <-c.Request().Context().Done():
+		// what services return when database cancels query
+		return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(c.Request().Context().Err(), "listsiswa: get data failed"))
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(zap.NewNop())
+	e.Use(TimeoutWithConfig(TimeoutConfig{
+		Timeout: func(c echo.Context) time.Duration {
+			if strings.HasSuffix(c.Path(), "-grid") {
+				return time.Second
+			}
+			return 20 * time.Millisecond
+		},
+	}))
+	e.GET("/fast", func(c echo.Context) error {
+		return slowQuery(c, 0)
+	})
+	e.GET("/slow", func(c echo.Context) error {
+		return slowQuery(c, time.Second)
+	})
+	e.GET("/slow-grid", func(c echo.Context) error {
+		return slowQuery(c, 100*time.Millisecond)
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return apierror.NewError(http.StatusNotFound, apierror.SiswaNotFound, "Siswa with id: 1 is not exists", errors.New("getsiswa: siswa with id: 1 is not exists"))
+	})
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testScenarios := []struct {
+		scenarioName   string
+		path           string
+		ctx            context.Context
+		expectedStatus int
+		expectedCode   apierror.Code
+	}{
+		{
+			scenarioName:   "within timeout",
+			path:           "/fast",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			scenarioName:   "past timeout",
+			path:           "/slow",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   apierror.RequestTimeout,
+		},
+		{
+			scenarioName:   "route with longer timeout",
+			path:           "/slow-grid",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			scenarioName:   "client disconnected",
+			path:           "/slow",
+			ctx:            canceled,
+			expectedStatus: apierror.StatusClientClosedRequest,
+			expectedCode:   apierror.RequestCanceled,
+		},
+		{
+			scenarioName:   "other error is kept",
+			path:           "/missing",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apierror.SiswaNotFound,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, v.path, nil).WithContext(v.ctx))
+
+			if rec.Code != v.expectedStatus {
+				t.Errorf("expect status %d, but got %d: %s", v.expectedStatus, rec.Code, rec.Body.String())
+				return
+			}
+			if v.expectedCode == "" {
+				return
+			}
+
+			r := response.Response{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &r); err != nil || len(r.Errors) != 1 {
+				t.Errorf("expect one error, but got %s", rec.Body.String())
+				return
+			}
+			if r.Errors[0].Code != string(v.expectedCode) {
+				t.Errorf("expect code %s, but got %s", v.expectedCode, r.Errors[0].Code)
+				return
+			}
+			if r.Errors[0].Title != apierror.StatusText(v.expectedStatus) {
+				t.Errorf("expect title %s, but got %s", apierror.StatusText(v.expectedStatus), r.Errors[0].Title)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/logging"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+type (
+	// TracingConfig defines the config for Tracing middleware.
+	TracingConfig struct {
+		Skipper Skipper
+		Logger  *zap.Logger
+	}
+)
+
+// TracingWithConfig returns a middleware that starts server span for every request,
+// continuing trace from incoming traceparent header. Trace id is added to request-scoped logger,
+// so register it after RequestIDWithConfig and before LoggerWithConfig.
+func TracingWithConfig(config TracingConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = defaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			if tenant := c.Param("tenant"); tenant != "" && c.Path() != "" {
+				// unmatched route can have any tenant, so only trust matched ones
+				ctx = tracing.WithTenant(ctx, tenant)
+			}
+
+			ctx, span := tracing.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("request_id", logging.RequestID(ctx)),
+				),
+			)
+			defer span.End()
+
+			if sc := span.SpanContext(); sc.IsValid() {
+				logger := logging.FromContext(ctx, config.Logger).With(
+					zap.String("trace_id", sc.TraceID().String()),
+					zap.String("span_id", sc.SpanID().String()),
+				)
+				ctx = logging.WithLogger(ctx, logger)
+			}
+			c.SetRequest(req.WithContext(ctx))
+
+			if err = next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(attribute.Int("http.status_code", status))
+			if status >= 500 {
+				span.SetStatus(codes.Error, "")
+			}
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+
+	"github.com/syukur91/ischool-monitor/pkg/logging"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var loggedTraceID bool
+	e := echo.New()
+	e.Use(TracingWithConfig(TracingConfig{Logger: zap.NewNop()}))
+	e.GET("/:tenant/hello", func(c echo.Context) error {
+		ctx, span := tracing.Start(c.Request().Context(), "HelloService.Hello")
+		span.End()
+
+		loggedTraceID = logging.FromContext(ctx, nil) != nil
+		return c.String(http.StatusOK, "hello")
+	})
+	e.GET("/:tenant/fail", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	})
+
+	testScenarios := []struct {
+		scenarioName    string
+		path            string
+		traceparent     string
+		expectedName    string
+		expectedTraceID string
+		expectedStatus  codes.Code
+		expectedSpans   int
+	}{
+		{
+			scenarioName:   "new trace with service child span",
+			path:           "/sekolah-a/hello",
+			expectedName:   "GET /:tenant/hello",
+			expectedStatus: codes.Unset,
+			expectedSpans:  2,
+		},
+		{
+			scenarioName:    "continue upstream trace",
+			path:            "/sekolah-a/hello",
+			traceparent:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedName:    "GET /:tenant/hello",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedStatus:  codes.Unset,
+			expectedSpans:   2,
+		},
+		{
+			scenarioName:   "server error marks span failed",
+			path:           "/sekolah-a/fail",
+			expectedName:   "GET /:tenant/fail",
+			expectedStatus: codes.Error,
+			expectedSpans:  1,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+			req := httptest.NewRequest(http.MethodGet, v.path, nil)
+			if v.traceparent != "" {
+				req.Header.Set("traceparent", v.traceparent)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			if len(spans) != v.expectedSpans {
+				t.Errorf("expect %d spans, but got %d", v.expectedSpans, len(spans))
+				return
+			}
+
+			// server span ends last
+			server := spans[len(spans)-1]
+			if server.Name() != v.expectedName {
+				t.Errorf("expect span name %s, but got %s", v.expectedName, server.Name())
+				return
+			}
+
+			if v.expectedTraceID != "" && server.SpanContext().TraceID().String() != v.expectedTraceID {
+				t.Errorf("expect trace id %s, but got %s", v.expectedTraceID, server.SpanContext().TraceID())
+				return
+			}
+
+			if server.Status().Code != v.expectedStatus {
+				t.Errorf("expect status %v, but got %v", v.expectedStatus, server.Status().Code)
+				return
+			}
+
+			if v.expectedSpans > 1 {
+				child := spans[0]
+				if child.Parent().SpanID() != server.SpanContext().SpanID() {
+					t.Errorf("expect service span to be child of server span")
+					return
+				}
+
+				tenant := ""
+				for _, a := range child.Attributes() {
+					if a.Key == attribute.Key("tenant") {
+						tenant = a.Value.AsString()
+					}
+				}
+				if tenant != "sekolah-a" {
+					t.Errorf("expect tenant sekolah-a, but got %s", tenant)
+					return
+				}
+
+				if !loggedTraceID {
+					t.Errorf("expect request logger in context")
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Package migrate applies SQL migrations from a file system, usually embedded in the binary.
+// Applied version is kept in schema_migrations like golang-migrate does, so databases migrated
+// by its CLI keep working.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// lockID is advisory lock key of migrations. Replicas starting together wait for each other
+// instead of applying the same migration twice.
+const lockID = 7240158315296342021
+
+var fileRegex = regexp.MustCompile(`^(\d+)_(.*)\.(up|down)\.sql$`)
+
+// Migration is a version with its up and down SQL
+type Migration struct {
+	Version int64
+	Title   string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and whether it is applied
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// Migrator applies migrations to database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+
+	// Log is called with every applied migration. Optional
+	Log func(direction string, m Migration)
+}
+
+// New reads migrations in root directory of fsys
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "new: list migration files failed")
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, f := range files {
+		m := fileRegex.FindStringSubmatch(f.Name())
+		if f.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "new: invalid version of "+f.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, f.Name())
+		if err != nil {
+			return nil, errors.Wrap(err, "new: read "+f.Name()+" failed")
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Title: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Title != m[2] {
+			return nil, errors.New("new: version " + m[1] + " has two titles, " + migration.Title + " and " + m[2])
+		}
+
+		if m[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns every migration in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest returns highest version, or 0 when there is no migration
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns applied version, 0 when nothing is applied. Dirty is true when a migration
+// failed halfway under another tool, and has to be fixed by hand.
+func (m *Migrator) Version(ctx context.Context) (version int64, dirty bool, err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "version: get connection failed")
+	}
+	defer conn.Close()
+
+	err = ensureTable(ctx, conn)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return currentVersion(ctx, conn)
+}
+
+// Status returns every migration and whether it is applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := []Status{}
+	for _, v := range m.migrations {
+		status = append(status, Status{Migration: v, Applied: v.Version <= version})
+	}
+
+	return status, nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the last n applied migrations
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.withLock(ctx, func(conn *sql.Conn, version int64) error {
+		return m.migrate(ctx, conn, version, m.downTarget(version, n))
+	})
+}
+
+// downTarget returns version that is left after reverting n migrations from version
+func (m *Migrator) downTarget(version int64, n int) int64 {
+	target := version
+	for i := len(m.migrations) - 1; i >= 0 && n > 0; i-- {
+		if m.migrations[i].Version > version {
+			continue
+		}
+
+		target = 0
+		if i > 0 {
+			target = m.migrations[i-1].Version
+		}
+		n--
+	}
+
+	return target
+}
+
+// To migrates up or down until version is applied. Version 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) < 0 {
+		return errors.New("to: migration version " + strconv.FormatInt(version, 10) + " is not exists")
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn, current int64) error {
+		return m.migrate(ctx, conn, current, version)
+	})
+}
+
+// withLock runs fn on one connection that holds advisory lock, with applied version read under the lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, version int64) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "lock: get connection failed")
+	}
+	defer conn.Close()
+
+	// session lock belongs to this connection, so every statement below runs on it
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID)
+	if err != nil {
+		return errors.Wrap(err, "lock: acquire advisory lock failed")
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	err = ensureTable(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := currentVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errors.New("lock: database is dirty at version " + strconv.FormatInt(version, 10) + ". Fix it by hand and set schema_migrations.dirty to false")
+	}
+
+	return fn(conn, version)
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, current int64, target int64) error {
+	// up
+	for _, v := range m.migrations {
+		if v.Version <= current || v.Version > target {
+			continue
+		}
+
+		if v.Up == "" {
+			return errors.New("migrate: " + strconv.FormatInt(v.Version, 10) + "_" + v.Title + " has no up migration")
+		}
+
+		err := apply(ctx, conn, v.Up, v.Version)
+		if err != nil {
+			return errors.Wrap(err, "migrate: up "+strconv.FormatInt(v.Version, 10)+"_"+v.Title+" failed")
+		}
+		if m.Log != nil {
+			m.Log("up", v)
+		}
+	}
+
+	// down, newest first
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		v := m.migrations[i]
+		if v.Version > current || v.Version <= target {
+			continue
+		}
+
+		previous := int64(0)
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+
+		if v.Down == "" {
+			return errors.New("migrate: " + strconv.FormatInt(v.Version, 10) + "_" + v.Title + " has no down migration")
+		}
+
+		err := apply(ctx, conn, v.Down, previous)
+		if err != nil {
+			return errors.Wrap(err, "migrate: down "+strconv.FormatInt(v.Version, 10)+"_"+v.Title+" failed")
+		}
+		if m.Log != nil {
+			m.Log("down", v)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) find(version int64) int {
+	for i, v := range m.migrations {
+		if v.Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// apply runs statements and records version in one transaction, so failed migration leaves nothing behind
+func apply(ctx context.Context, conn *sql.Conn, statements string, version int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "apply: begin transaction failed")
+	}
+
+	_, err = tx.ExecContext(ctx, statements)
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "apply: exec migration failed")
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations")
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "apply: clear version failed")
+	}
+
+	if version > 0 {
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", version)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "apply: set version failed")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "apply: commit transaction failed")
+	}
+
+	return nil
+}
+
+func ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)")
+	if err != nil {
+		return errors.Wrap(err, "ensuretable: create schema_migrations failed")
+	}
+
+	return nil
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrap(err, "currentversion: get version failed")
+	}
+
+	return version, dirty, nil
+}
@@ -0,0 +1,107 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestNew(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName     string
+		files            fstest.MapFS
+		expectedVersions []int64
+		expectedErr      bool
+	}{
+		{
+			scenarioName: "sorted by version, other files skipped",
+			files: fstest.MapFS{
+				"0010_siswa.up.sql":    {Data: []byte("CREATE TABLE siswa ();")},
+				"0010_siswa.down.sql":  {Data: []byte("DROP TABLE siswa;")},
+				"0002_kelas.up.sql":    {Data: []byte("CREATE TABLE kelas ();")},
+				"0002_kelas.down.sql":  {Data: []byte("DROP TABLE kelas;")},
+				"migration.go":         {Data: []byte("package migration")},
+				"0003_notes.md":        {Data: []byte("notes")},
+				"0001_school.up.sql":   {Data: []byte("CREATE TABLE school ();")},
+				"0001_school.down.sql": {Data: []byte("DROP TABLE school;")},
+			},
+			expectedVersions: []int64{1, 2, 10},
+		},
+		{
+			scenarioName: "same version with two titles",
+			files: fstest.MapFS{
+				"0001_school.up.sql":   {Data: []byte("CREATE TABLE school ();")},
+				"0001_sekolah.up.sql":  {Data: []byte("CREATE TABLE sekolah ();")},
+				"0001_school.down.sql": {Data: []byte("DROP TABLE school;")},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			m, err := New(nil, v.files)
+			if v.expectedErr {
+				if err == nil {
+					t.Errorf("expect error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			versions := []int64{}
+			for _, migration := range m.Migrations() {
+				versions = append(versions, migration.Version)
+				if migration.Up == "" || migration.Down == "" {
+					t.Errorf("expect up and down of version %d", migration.Version)
+					return
+				}
+			}
+
+			if len(versions) != len(v.expectedVersions) {
+				t.Errorf("expect versions %v, but got %v", v.expectedVersions, versions)
+				return
+			}
+			for i := range versions {
+				if versions[i] != v.expectedVersions[i] {
+					t.Errorf("expect versions %v, but got %v", v.expectedVersions, versions)
+					return
+				}
+			}
+
+			if m.Latest() != v.expectedVersions[len(v.expectedVersions)-1] {
+				t.Errorf("expect latest %d, but got %d", v.expectedVersions[len(v.expectedVersions)-1], m.Latest())
+				return
+			}
+		})
+	}
+}
+
+func TestMigrator_DownTarget(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 1}, {Version: 2}, {Version: 10}}}
+
+	testScenarios := []struct {
+		scenarioName   string
+		version        int64
+		n              int
+		expectedTarget int64
+	}{
+		{scenarioName: "one step from latest", version: 10, n: 1, expectedTarget: 2},
+		{scenarioName: "two steps from latest", version: 10, n: 2, expectedTarget: 1},
+		{scenarioName: "more steps than applied", version: 2, n: 5, expectedTarget: 0},
+		{scenarioName: "nothing applied", version: 0, n: 1, expectedTarget: 0},
+		{scenarioName: "zero steps", version: 10, n: 0, expectedTarget: 10},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			target := m.downTarget(v.version, v.n)
+			if target != v.expectedTarget {
+				t.Errorf("expect target %d, but got %d", v.expectedTarget, target)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"embed"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+// swaggerUI has swagger-ui.css and swagger-ui-bundle.js of swagger-ui-dist 5.18.2, with its page and initializer
+//
+//go:embed swagger-ui
+var swaggerUI embed.FS
+
+// Handler serves document of DefaultRegistry for routes of e under prefix, built on every request
+// so routes registered after it are included. Server URL is prefix with path params of request,
+// e.g. /sekolah-a for /:tenant.
+func Handler(e *echo.Echo, prefix string, info Info) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		d := DefaultRegistry.Build(info, e.Routes(), prefix)
+		d.Servers = []Server{{URL: serverURL(c, prefix)}}
+
+		return c.JSON(http.StatusOK, d)
+	}
+}
+
+// UIHandler serves embedded Swagger UI under docs/ that loads openapi.json next to it, e.g. /:tenant/docs/
+// loads /:tenant/openapi.json. Register it for both /docs and /docs/*, docs is redirected to docs/.
+func UIHandler(c echo.Context) error {
+	file := c.Param("*")
+	if file == "" && !strings.HasSuffix(c.Request().URL.Path, "/") {
+		return c.Redirect(http.StatusMovedPermanently, c.Request().URL.Path+"/")
+	}
+
+	if file == "" {
+		file = "index.html"
+	}
+
+	b, err := swaggerUI.ReadFile(path.Join("swagger-ui", path.Clean("/"+file)))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	return c.Blob(http.StatusOK, mime.TypeByExtension(path.Ext(file)), b)
+}
+
+func serverURL(c echo.Context, prefix string) string {
+	segments := strings.Split(prefix, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = c.Param(s[1:])
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,290 @@
+// Package openapi builds OpenAPI 3 document of echo routes. Routes are read from echo, so the
+// document always matches what is served. Request and response types of a route are described
+// by its handler with Describe, usually next to the route in SetRoutes.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/query"
+	"github.com/syukur91/ischool-monitor/pkg/response"
+)
+
+// Version of OpenAPI specification of built documents
+const Version = "3.0.3"
+
+// methods that OpenAPI can describe. Routes of other methods, e.g. added by echo Any, are left out
+var methods = map[string]bool{"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true}
+
+// groupNotFound prefixes name of not found handlers that echo adds to every group prefix
+const groupNotFound = "github.com/labstack/echo.(*Group).Use."
+
+// Operation describes request and response of a handler
+type Operation struct {
+	Summary string
+	Tag     string
+
+	// Request is request body type, e.g. schema.CreateKelasRequest{}. Nil when there is no body
+	Request interface{}
+
+	// RequestContentType is content type of request body. Optional. Default application/json
+	RequestContentType string
+
+	// Response is type of data in response envelope. Nil when response has no body
+	Response interface{}
+
+	// Grid marks Kendo grid endpoints: request body is query.GridParams and
+	// response is a page of Response with total count
+	Grid bool
+
+	// Query are query parameters that operation accepts, see QueryParam
+	Query []Parameter
+}
+
+// Info is API title and version shown in document
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*PathEntry `json:"paths"`
+	Components Components                       `json:"components"`
+
+	types map[string]reflect.Type
+}
+
+// Server is base URL of paths
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Components holds schemas referenced by $ref
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathEntry is an operation of a path and method
+type PathEntry struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// QueryParam returns optional string query parameter, e.g. QueryParam("tanggal", "Date as YYYY-MM-DD")
+func QueryParam(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+// RequestBody is JSON request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Registry holds operations of handlers, keyed by handler name as echo names routes
+type Registry struct {
+	mu         sync.RWMutex
+	operations map[string]Operation
+}
+
+// NewRegistry ...
+func NewRegistry() *Registry {
+	return &Registry{operations: map[string]Operation{}}
+}
+
+// DefaultRegistry is used by Describe and Handler
+var DefaultRegistry = NewRegistry()
+
+// Describe describes handler in DefaultRegistry
+func Describe(handler echo.HandlerFunc, op Operation) {
+	DefaultRegistry.Describe(handler, op)
+}
+
+// Describe describes handler. Describing a handler again replaces the operation.
+func (r *Registry) Describe(handler echo.HandlerFunc, op Operation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.operations[handlerName(handler)] = op
+}
+
+func (r *Registry) operation(name string) (Operation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	op, ok := r.operations[name]
+	return op, ok
+}
+
+// Build returns document of routes under prefix, e.g. /:tenant. Prefix is left out of paths,
+// so it belongs to server URL. Routes without description are listed without schemas.
+func (r *Registry) Build(info Info, routes []*echo.Route, prefix string) *Document {
+	d := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]map[string]*PathEntry{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		types:      map[string]reflect.Type{},
+	}
+
+	// envelope of every JSON response and error
+	envelope := d.schemaRef(reflect.TypeOf(response.Response{}))
+
+	sorted := make([]*echo.Route, len(routes))
+	copy(sorted, routes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		if !strings.HasPrefix(route.Path, prefix) || strings.Contains(route.Path, "*") {
+			continue
+		}
+		if strings.HasPrefix(route.Name, groupNotFound) {
+			continue
+		}
+		method := strings.ToLower(route.Method)
+		if !methods[method] {
+			continue
+		}
+
+		path, params := openAPIPath(strings.TrimPrefix(route.Path, prefix))
+		entry := &PathEntry{
+			Parameters: params,
+			Responses: map[string]*Response{
+				"default": {Description: "Error", Content: jsonContent(envelope)},
+			},
+		}
+
+		op, ok := r.operation(route.Name)
+		if ok {
+			entry.OperationID = operationID(route.Name)
+			entry.Summary = op.Summary
+			if op.Tag != "" {
+				entry.Tags = []string{op.Tag}
+			}
+			d.describe(entry, op, envelope)
+		} else {
+			entry.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+		}
+
+		if d.Paths[path] == nil {
+			d.Paths[path] = map[string]*PathEntry{}
+		}
+		d.Paths[path][method] = entry
+	}
+
+	return d
+}
+
+func (d *Document) describe(entry *PathEntry, op Operation, envelope *Schema) {
+	success := &Response{Description: http.StatusText(http.StatusOK)}
+	entry.Responses["200"] = success
+	entry.Parameters = append(entry.Parameters, op.Query...)
+
+	if op.Grid {
+		entry.RequestBody = &RequestBody{Required: true, Content: jsonContent(d.schemaRef(reflect.TypeOf(query.GridParams{})))}
+
+		items := &Schema{}
+		if op.Response != nil {
+			items = d.schemaRef(reflect.TypeOf(op.Response))
+		}
+		success.Content = jsonContent(&Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"data":  {Type: "array", Items: items},
+				"total": {Type: "integer"},
+			},
+		})
+		return
+	}
+
+	if op.Request != nil {
+		content := jsonContent(d.schemaRef(reflect.TypeOf(op.Request)))
+		if op.RequestContentType != "" {
+			content = map[string]MediaType{op.RequestContentType: content[echo.MIMEApplicationJSON]}
+		}
+		entry.RequestBody = &RequestBody{Required: true, Content: content}
+	}
+
+	if op.Response != nil {
+		success.Content = jsonContent(&Schema{AllOf: []*Schema{
+			envelope,
+			{Type: "object", Properties: map[string]*Schema{"data": d.schemaRef(reflect.TypeOf(op.Response))}},
+		}})
+	}
+}
+
+// openAPIPath converts echo path params, e.g. /kelass/:id, to /kelass/{id}
+func openAPIPath(path string) (string, []Parameter) {
+	params := []Parameter{}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			name := s[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	path = strings.Join(segments, "/")
+	if path == "" {
+		path = "/"
+	}
+
+	return path, params
+}
+
+// operationID is method name of handler, e.g. createKelas of controller.(*KelasHandler).createKelas-fm
+func operationID(name string) string {
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{echo.MIMEApplicationJSON: {Schema: s}}
+}
+
+// handlerName is the same name echo gives to route of handler
+func handlerName(h echo.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+}
@@ -0,0 +1,346 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+type createItemRequest struct {
+	Nama    string   `json:"nama" validate:"required,max=64"`
+	Email   string   `json:"email" validate:"omitempty,email"`
+	Tingkat int      `json:"tingkat" validate:"required,min=1,max=12"`
+	Status  string   `json:"status" validate:"oneof=aktif lulus"`
+	Tags    []string `json:"tags" validate:"max=3"`
+	secret  string
+}
+
+type itemResponse struct {
+	ID        int        `json:"id"`
+	Nama      string     `json:"nama"`
+	Alamat    *string    `json:"alamat"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Internal  string     `json:"-"`
+}
+
+type updateItemRequest struct {
+	Nama   optional.Field[string]  `json:"nama"`
+	Alamat optional.Field[*string] `json:"alamat"`
+}
+
+func noop(c echo.Context) error { return nil }
+
+func createItem(c echo.Context) error { return nil }
+
+func gridItems(c echo.Context) error { return nil }
+
+func getItem(c echo.Context) error { return nil }
+
+func updateItem(c echo.Context) error { return nil }
+
+func TestRegistry_Build(t *testing.T) {
+	e := echo.New()
+	r := e.Group("/:tenant")
+	r.POST("/items", createItem)
+	r.POST("/items-grid", gridItems)
+	r.GET("/items/:id", getItem)
+	r.PATCH("/items/:id", updateItem)
+	r.GET("/hello", noop)
+	e.GET("/metrics", noop)
+
+	registry := NewRegistry()
+	registry.Describe(createItem, Operation{Summary: "Create item", Tag: "Item", Request: createItemRequest{}, Response: itemResponse{}})
+	registry.Describe(gridItems, Operation{Summary: "List item", Tag: "Item", Response: itemResponse{}, Grid: true})
+	registry.Describe(getItem, Operation{Summary: "Get item", Tag: "Item", Response: itemResponse{}})
+	registry.Describe(updateItem, Operation{Summary: "Update item", Tag: "Item", Request: updateItemRequest{}, RequestContentType: optional.MIMEApplicationMergePatchJSON, Response: itemResponse{}})
+
+	d := registry.Build(Info{Title: "test", Version: "1"}, e.Routes(), "/:tenant")
+
+	if d.OpenAPI != Version {
+		t.Errorf("expect openapi %s, but got %s", Version, d.OpenAPI)
+		return
+	}
+
+	if _, ok := d.Paths["/metrics"]; ok {
+		t.Errorf("expect route outside prefix to be left out")
+		return
+	}
+
+	if _, ok := d.Paths["/"]; ok {
+		t.Errorf("expect not found routes of group to be left out")
+		return
+	}
+
+	testScenarios := []struct {
+		scenarioName        string
+		path                string
+		method              string
+		expectedOperationID string
+		expectedParams      int
+		requestContentType  string
+		expectedRequestRef  string
+		expectedDataRef     string
+	}{
+		{
+			scenarioName:        "create",
+			path:                "/items",
+			method:              "post",
+			expectedOperationID: "createItem",
+			expectedRequestRef:  "#/components/schemas/createItemRequest",
+			expectedDataRef:     "#/components/schemas/itemResponse",
+		},
+		{
+			scenarioName:        "grid",
+			path:                "/items-grid",
+			method:              "post",
+			expectedOperationID: "gridItems",
+			expectedRequestRef:  "#/components/schemas/GridParams",
+		},
+		{
+			scenarioName:        "get with path param",
+			path:                "/items/{id}",
+			method:              "get",
+			expectedOperationID: "getItem",
+			expectedParams:      1,
+			expectedDataRef:     "#/components/schemas/itemResponse",
+		},
+		{
+			scenarioName:        "merge patch",
+			path:                "/items/{id}",
+			method:              "patch",
+			expectedOperationID: "updateItem",
+			expectedParams:      1,
+			requestContentType:  optional.MIMEApplicationMergePatchJSON,
+			expectedRequestRef:  "#/components/schemas/updateItemRequest",
+			expectedDataRef:     "#/components/schemas/itemResponse",
+		},
+		{
+			scenarioName: "undescribed route",
+			path:         "/hello",
+			method:       "get",
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			entry, ok := d.Paths[v.path][v.method]
+			if !ok {
+				t.Errorf("expect %s %s in paths, but got %v", v.method, v.path, d.Paths)
+				return
+			}
+
+			if entry.OperationID != v.expectedOperationID {
+				t.Errorf("expect operation id %s, but got %s", v.expectedOperationID, entry.OperationID)
+				return
+			}
+
+			if len(entry.Parameters) != v.expectedParams {
+				t.Errorf("expect %d params, but got %d", v.expectedParams, len(entry.Parameters))
+				return
+			}
+
+			contentType := v.requestContentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+			requestRef := ""
+			if entry.RequestBody != nil {
+				requestRef = entry.RequestBody.Content[contentType].Schema.Ref
+			}
+			if requestRef != v.expectedRequestRef {
+				t.Errorf("expect request %s, but got %s", v.expectedRequestRef, requestRef)
+				return
+			}
+
+			dataRef := ""
+			if content, ok := entry.Responses["200"].Content[echo.MIMEApplicationJSON]; ok && len(content.Schema.AllOf) == 2 {
+				dataRef = content.Schema.AllOf[1].Properties["data"].Ref
+			}
+			if dataRef != v.expectedDataRef {
+				t.Errorf("expect data %s, but got %s", v.expectedDataRef, dataRef)
+				return
+			}
+		})
+	}
+
+	request := d.Components.Schemas["createItemRequest"]
+	if !reflect.DeepEqual(request.Required, []string{"nama", "tingkat"}) {
+		t.Errorf("expect required [nama tingkat], but got %v", request.Required)
+		return
+	}
+	if _, ok := request.Properties["secret"]; ok {
+		t.Errorf("expect unexported field to be left out")
+		return
+	}
+
+	patch := d.Components.Schemas["updateItemRequest"]
+	if patch.Properties["nama"].Type != "string" || patch.Properties["nama"].Nullable {
+		t.Errorf("expect optional field to be its value, but got %+v", patch.Properties["nama"])
+		return
+	}
+	if !patch.Properties["alamat"].Nullable {
+		t.Errorf("expect optional pointer field to be nullable")
+		return
+	}
+
+	response := d.Components.Schemas["itemResponse"]
+	if _, ok := response.Properties["Internal"]; ok {
+		t.Errorf("expect json:\"-\" field to be left out")
+		return
+	}
+	if !response.Properties["alamat"].Nullable {
+		t.Errorf("expect pointer field to be nullable")
+		return
+	}
+	if response.Properties["created_at"].Format != "date-time" {
+		t.Errorf("expect time format date-time, but got %s", response.Properties["created_at"].Format)
+		return
+	}
+
+	for _, name := range []string{"Response", "Error", "GridParams", "GridFilter", "GridSort"} {
+		if _, ok := d.Components.Schemas[name]; !ok {
+			t.Errorf("expect component %s", name)
+			return
+		}
+	}
+}
+
+func TestApplyValidate(t *testing.T) {
+	one, twelve, sixtyFour, three := 1.0, 12.0, 64, 3
+
+	testScenarios := []struct {
+		scenarioName     string
+		schema           Schema
+		tag              string
+		expected         Schema
+		expectedRequired bool
+	}{
+		{
+			scenarioName:     "required string with max length",
+			schema:           Schema{Type: "string"},
+			tag:              "required,max=64",
+			expected:         Schema{Type: "string", MaxLength: &sixtyFour},
+			expectedRequired: true,
+		},
+		{
+			scenarioName: "number range",
+			schema:       Schema{Type: "integer"},
+			tag:          "min=1,max=12",
+			expected:     Schema{Type: "integer", Minimum: &one, Maximum: &twelve},
+		},
+		{
+			scenarioName: "array count",
+			schema:       Schema{Type: "array"},
+			tag:          "max=3",
+			expected:     Schema{Type: "array", MaxItems: &three},
+		},
+		{
+			scenarioName: "email and oneof",
+			schema:       Schema{Type: "string"},
+			tag:          "omitempty,email,oneof=a b",
+			expected:     Schema{Type: "string", Format: "email", Enum: []string{"a", "b"}},
+		},
+		{
+			scenarioName:     "reference only takes required",
+			schema:           Schema{Ref: "#/components/schemas/GridParams"},
+			tag:              "required,min=1",
+			expected:         Schema{Ref: "#/components/schemas/GridParams"},
+			expectedRequired: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			required := applyValidate(&v.schema, v.tag)
+			if required != v.expectedRequired {
+				t.Errorf("expect required %t, but got %t", v.expectedRequired, required)
+				return
+			}
+
+			if !reflect.DeepEqual(v.schema, v.expected) {
+				t.Errorf("expect %+v, but got %+v", v.expected, v.schema)
+				return
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	e := echo.New()
+	r := e.Group("/:tenant")
+	r.GET("/items/:id", getItem)
+	r.GET("/openapi.json", Handler(e, "/:tenant", Info{Title: "test", Version: "1"}))
+	r.GET("/docs", UIHandler)
+	r.GET("/docs/*", UIHandler)
+
+	Describe(getItem, Operation{Summary: "Get item", Response: itemResponse{}, Query: []Parameter{QueryParam("tanggal", "Date")}})
+
+	testScenarios := []struct {
+		scenarioName        string
+		path                string
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{scenarioName: "document", path: "/sekolah-a/openapi.json", expectedStatus: http.StatusOK, expectedContentType: echo.MIMEApplicationJSONCharsetUTF8},
+		{scenarioName: "swagger ui", path: "/sekolah-a/docs/", expectedStatus: http.StatusOK, expectedContentType: "text/html; charset=utf-8"},
+		{scenarioName: "swagger ui style", path: "/sekolah-a/docs/swagger-ui.css", expectedStatus: http.StatusOK, expectedContentType: "text/css; charset=utf-8"},
+		{scenarioName: "swagger ui script", path: "/sekolah-a/docs/swagger-ui-bundle.js", expectedStatus: http.StatusOK, expectedContentType: "text/javascript; charset=utf-8"},
+		{scenarioName: "swagger ui without slash", path: "/sekolah-a/docs", expectedStatus: http.StatusMovedPermanently},
+		{scenarioName: "swagger ui missing file", path: "/sekolah-a/docs/missing.js", expectedStatus: http.StatusNotFound},
+		{scenarioName: "swagger ui outside its directory", path: "/sekolah-a/docs/../handler.go", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, v.path, nil))
+
+			if rec.Code != v.expectedStatus {
+				t.Errorf("expect status %d, but got %d", v.expectedStatus, rec.Code)
+				return
+			}
+
+			if v.expectedContentType == "" {
+				return
+			}
+
+			if rec.Header().Get(echo.HeaderContentType) != v.expectedContentType {
+				t.Errorf("expect content type %s, but got %s", v.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+				return
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sekolah-a/openapi.json", nil))
+
+	d := Document{}
+	err := json.Unmarshal(rec.Body.Bytes(), &d)
+	if err != nil {
+		t.Errorf("expect valid json, but got %s", err)
+		return
+	}
+
+	if len(d.Servers) != 1 || d.Servers[0].URL != "/sekolah-a" {
+		t.Errorf("expect server /sekolah-a, but got %v", d.Servers)
+		return
+	}
+
+	if d.Paths["/items/{id}"]["get"].Summary != "Get item" {
+		t.Errorf("expect described item route, but got %v", d.Paths)
+		return
+	}
+
+	params := d.Paths["/items/{id}"]["get"].Parameters
+	if len(params) != 2 || params[1].Name != "tanggal" || params[1].In != "query" {
+		t.Errorf("expect path id and query tanggal, but got %+v", params)
+		return
+	}
+}
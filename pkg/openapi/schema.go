@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+// Schema is an OpenAPI 3.0 schema object. Only the keywords used by this API are supported.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Description          string             `json:"description,omitempty"`
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	optionalType = reflect.TypeOf((*optional.Optional)(nil)).Elem()
+)
+
+// schemaRef returns schema of t. Named structs are added to components once and referenced by $ref,
+// which also keeps recursive types such as query.GridFilter finite.
+func (d *Document) schemaRef(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := d.schemaOf(t)
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+
+	return s
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(optionalType):
+		// optional field is its value in JSON, absent when not set
+		return d.schemaRef(reflect.Zero(t).Interface().(optional.Optional).ValueType())
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := d.componentName(t)
+		if _, ok := d.Components.Schemas[name]; !ok {
+			// register before building properties, so recursive fields find it
+			d.Components.Schemas[name] = &Schema{}
+			d.types[name] = t
+			*d.Components.Schemas[name] = *d.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return d.structSchema(t)
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaRef(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaRef(t.Elem())}
+	}
+
+	// interface{} accepts any value
+	return &Schema{}
+}
+
+// componentName is type name, prefixed by package name when another package already uses it
+func (d *Document) componentName(t reflect.Type) string {
+	name := t.Name()
+	if other, ok := d.types[name]; ok && other != t {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	return name
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+
+		// embedded struct without json name is flattened like encoding/json does
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := d.structSchema(ft)
+				for k, v := range embedded.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, embedded.Required...)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		property := d.schemaRef(f.Type)
+		if applyValidate(property, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+
+	return s
+}
+
+// jsonName returns json name of exported field. Name is empty when tag does not set it.
+func jsonName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" && !f.Anonymous {
+		return "", false
+	}
+
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	return strings.Split(tag, ",")[0], true
+}
+
+// applyValidate maps validator.v9 tags to schema keywords and returns true when field is required.
+// Tags without an OpenAPI equivalent are ignored.
+func applyValidate(s *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param := rule, ""
+		if i := strings.Index(rule, "="); i > -1 {
+			name, param = rule[:i], rule[i+1:]
+		}
+
+		// limits of a $ref can not be set next to it, so only required applies
+		if s.Ref != "" && name != "required" {
+			continue
+		}
+
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "url", "uri":
+			s.Format = "uri"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			applyLimit(s, name, param)
+		}
+	}
+
+	return required
+}
+
+// applyLimit sets length of strings, count of arrays or value of numbers, as validator does for the type
+func applyLimit(s *Schema, name string, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch s.Type {
+	case "string", "array":
+		length := int(n)
+		min, max := &s.MinLength, &s.MaxLength
+		if s.Type == "array" {
+			min, max = &s.MinItems, &s.MaxItems
+		}
+		switch name {
+		case "min", "gte":
+			*min = &length
+		case "max", "lte":
+			*max = &length
+		case "gt":
+			length++
+			*min = &length
+		case "lt":
+			length--
+			*max = &length
+		case "len":
+			*min, *max = &length, &length
+		}
+	case "integer", "number":
+		switch name {
+		case "min", "gte":
+			s.Minimum = &n
+		case "max", "lte":
+			s.Maximum = &n
+		case "gt":
+			s.Minimum, s.ExclusiveMinimum = &n, true
+		case "lt":
+			s.Maximum, s.ExclusiveMaximum = &n, true
+		case "len":
+			s.Minimum, s.Maximum = &n, &n
+		}
+	}
+}
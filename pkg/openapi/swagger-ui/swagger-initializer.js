@@ -0,0 +1,8 @@
+// document is served next to docs, e.g. /sekolah-a/openapi.json for /sekolah-a/docs/
+window.onload = function () {
+  window.ui = SwaggerUIBundle({
+    url: new URL("../openapi.json", window.location.href).pathname,
+    dom_id: "#swagger-ui",
+    deepLinking: true
+  });
+};
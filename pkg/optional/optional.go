@@ -0,0 +1,95 @@
+// Package optional tells absent JSON fields apart from null and zero ones, as JSON Merge Patch
+// (RFC 7396) needs. Update requests use Field for every column:
+//
+//	{}                 nama is not set, it keeps its value
+//	{"nama": null}     nama is set to null
+//	{"nama": ""}       nama is set to ""
+//
+// Field of nullable column has pointer type, e.g. Field[*string], so null is its nil Value.
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// MIMEApplicationMergePatchJSON is content type of JSON Merge Patch
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// Field is field of request that may be absent, null or have value
+type Field[T any] struct {
+	// Set is true when field is present in request, also when it is null
+	Set bool
+
+	// Null is true when field is null
+	Null bool
+
+	Value T
+}
+
+// Optional is implemented by Field of every type, so reflection can read it without knowing T
+type Optional interface {
+	IsSet() bool
+	IsNull() bool
+
+	// Interface returns Value
+	Interface() interface{}
+
+	// ValueType returns T
+	ValueType() reflect.Type
+}
+
+var null = []byte("null")
+
+// Of returns field set to v
+func Of[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+// Null returns field set to null
+func Null[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+// IsSet ...
+func (f Field[T]) IsSet() bool {
+	return f.Set
+}
+
+// IsNull ...
+func (f Field[T]) IsNull() bool {
+	return f.Null
+}
+
+// Interface ...
+func (f Field[T]) Interface() interface{} {
+	return f.Value
+}
+
+// ValueType ...
+func (f Field[T]) ValueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// UnmarshalJSON sets field. encoding/json calls it only for fields present in JSON, null included.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	var value T
+	f.Set, f.Null, f.Value = true, false, value
+
+	if bytes.Equal(bytes.TrimSpace(data), null) {
+		f.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &f.Value)
+}
+
+// MarshalJSON writes Value, and null when field is absent or null
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.Set || f.Null {
+		return null, nil
+	}
+
+	return json.Marshal(f.Value)
+}
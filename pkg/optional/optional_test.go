@@ -0,0 +1,100 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testRequest struct {
+	Nama    Field[string]  `json:"nama"`
+	Tingkat Field[int]     `json:"tingkat"`
+	Alamat  Field[*string] `json:"alamat"`
+}
+
+func TestField_UnmarshalJSON(t *testing.T) {
+	alamat := "Jl. Merdeka"
+
+	testScenarios := []struct {
+		scenarioName  string
+		body          string
+		expected      testRequest
+		expectedError bool
+	}{
+		{
+			scenarioName: "absent fields are not set",
+			body:         `{}`,
+			expected:     testRequest{},
+		},
+		{
+			scenarioName: "null is set",
+			body:         `{"nama": null, "alamat": null}`,
+			expected:     testRequest{Nama: Null[string](), Alamat: Null[*string]()},
+		},
+		{
+			scenarioName: "zero values are set",
+			body:         `{"nama": "", "tingkat": 0}`,
+			expected:     testRequest{Nama: Of(""), Tingkat: Of(0)},
+		},
+		{
+			scenarioName: "value of pointer field",
+			body:         `{"alamat": "Jl. Merdeka"}`,
+			expected:     testRequest{Alamat: Of(&alamat)},
+		},
+		{
+			scenarioName:  "value of wrong type",
+			body:          `{"tingkat": "satu"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			request := testRequest{}
+			err := json.Unmarshal([]byte(v.body), &request)
+
+			if (err != nil) != v.expectedError {
+				t.Errorf("expect error %t, but got %v", v.expectedError, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(request, v.expected) {
+				t.Errorf("expect request %+v, but got %+v", v.expected, request)
+				return
+			}
+		})
+	}
+}
+
+func TestField_MarshalJSON(t *testing.T) {
+	request := testRequest{Nama: Of("Budi"), Alamat: Null[*string]()}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	expected := `{"nama":"Budi","tingkat":null,"alamat":null}`
+	if string(body) != expected {
+		t.Errorf("expect body %s, but got %s", expected, body)
+		return
+	}
+}
+
+func TestField_Optional(t *testing.T) {
+	var o Optional = Of(3)
+
+	if !o.IsSet() || o.IsNull() || o.Interface() != 3 {
+		t.Errorf("expect set field with value 3, but got %+v", o)
+		return
+	}
+
+	if o.ValueType() != reflect.TypeOf(0) {
+		t.Errorf("expect value type int, but got %s", o.ValueType())
+		return
+	}
+}
@@ -0,0 +1,104 @@
+// Package period scopes lists to a semester. Request carries semester parameter in context, and
+// grid of scoped model only lists rows of tahun ajaran of that semester, or of active semester
+// when parameter is not sent. Semester is looked up lazily, only by grids that are scoped.
+//
+// Usage in grid handler:
+//
+//	err := period.Scope(ctx, gridParams, "tahun_ajaran")
+package period
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/pkg/query"
+)
+
+const (
+	// Param is query parameter that chooses semester by id
+	Param = "semester"
+
+	// All is value of Param that lists rows of every period
+	All = "all"
+
+	// Description describes Param in API document
+	Description = "Semester id, or " + All + " for every semester. Default active semester"
+)
+
+// Period is a semester of tahun ajaran, from mulai to akhir
+type Period struct {
+	SemesterID  int
+	TahunAjaran int
+	Mulai       time.Time
+	Akhir       time.Time
+}
+
+// Resolver returns period of semester with id, or of active semester when id is empty. It
+// returns nil when no semester is active.
+type Resolver func(ctx context.Context, id string) (*Period, error)
+
+type lookupKey struct{}
+
+// lookup is semester parameter of request with resolver of its period
+type lookup struct {
+	resolver Resolver
+	semester string
+}
+
+// WithSemester returns ctx that carries semester parameter of request, resolved by resolver when
+// a scoped grid needs it
+func WithSemester(ctx context.Context, resolver Resolver, semester string) context.Context {
+	return context.WithValue(ctx, lookupKey{}, lookup{resolver: resolver, semester: semester})
+}
+
+// FromContext returns period of request. It is nil when request lists every period, when no
+// semester is active or when ctx has no semester parameter.
+func FromContext(ctx context.Context) (*Period, error) {
+	l, ok := ctx.Value(lookupKey{}).(lookup)
+	if !ok || l.semester == All {
+		return nil, nil
+	}
+
+	return l.resolver(ctx, l.semester)
+}
+
+// Scope adds filter of field equal to tahun ajaran of period to grid params, and it with filters
+// of request. Grid that already filters field, or has no period, is left as it is.
+func Scope(ctx context.Context, gridParams *query.GridParams, field string) error {
+	if filters(gridParams.Filter.Filters, field) {
+		return nil
+	}
+
+	p, err := FromContext(ctx)
+	if err != nil || p == nil {
+		return err
+	}
+
+	scope := query.GridFilter{Field: field, Operator: "eq", Value: strconv.Itoa(p.TahunAjaran)}
+	switch {
+	case len(gridParams.Filter.Filters) == 0:
+		gridParams.Filter = query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{scope}}
+	case len(gridParams.Filter.Filters) == 1 || strings.ToLower(gridParams.Filter.Logic) != "or":
+		gridParams.Filter.Logic = "and"
+		gridParams.Filter.Filters = append(gridParams.Filter.Filters, scope)
+	default:
+		request := query.GridFilter{HasSubFilter: true, Logic: gridParams.Filter.Logic, Filters: gridParams.Filter.Filters}
+		gridParams.Filter = query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{request, scope}}
+	}
+	gridParams.HasFilter = true
+
+	return nil
+}
+
+// filters returns true when any of filters, or their nested filters, is on field
+func filters(fs []query.GridFilter, field string) bool {
+	for _, f := range fs {
+		if f.Field == field || filters(f.Filters, field) {
+			return true
+		}
+	}
+
+	return false
+}
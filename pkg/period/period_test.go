@@ -0,0 +1,126 @@
+package period
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/syukur91/ischool-monitor/pkg/query"
+)
+
+func TestScope(t *testing.T) {
+	active := func(ctx context.Context, id string) (*Period, error) {
+		if id == "" {
+			return &Period{SemesterID: 1, TahunAjaran: 2026}, nil
+		}
+		return &Period{SemesterID: 2, TahunAjaran: 2025}, nil
+	}
+	none := func(ctx context.Context, id string) (*Period, error) { return nil, nil }
+	failed := func(ctx context.Context, id string) (*Period, error) {
+		return nil, errors.New("semester is not exists")
+	}
+
+	nama := query.GridFilter{Field: "nama", Operator: "contains", Value: "A"}
+	tingkat := query.GridFilter{Field: "tingkat", Operator: "eq", Value: "1"}
+
+	testScenarios := []struct {
+		scenarioName   string
+		ctx            context.Context
+		filter         query.GridFilterMain
+		expectedFilter query.GridFilterMain
+		expectedErr    bool
+	}{
+		{
+			scenarioName:   "request without semester parameter is not scoped",
+			ctx:            context.Background(),
+			expectedFilter: query.GridFilterMain{},
+		},
+		{
+			scenarioName: "grid without filters is scoped to active semester",
+			ctx:          WithSemester(context.Background(), active, ""),
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{
+				{Field: "tahun_ajaran", Operator: "eq", Value: "2026"},
+			}},
+		},
+		{
+			scenarioName: "grid is scoped to semester of parameter",
+			ctx:          WithSemester(context.Background(), active, "2"),
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{
+				{Field: "tahun_ajaran", Operator: "eq", Value: "2025"},
+			}},
+		},
+		{
+			scenarioName:   "grid of every period is not scoped",
+			ctx:            WithSemester(context.Background(), active, All),
+			filter:         query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{nama}},
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{nama}},
+		},
+		{
+			scenarioName:   "grid without active semester is not scoped",
+			ctx:            WithSemester(context.Background(), none, ""),
+			filter:         query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{nama}},
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{nama}},
+		},
+		{
+			scenarioName: "grid that filters tahun ajaran keeps its filter",
+			ctx:          WithSemester(context.Background(), active, ""),
+			filter: query.GridFilterMain{Logic: "or", Filters: []query.GridFilter{
+				nama,
+				{HasSubFilter: true, Logic: "and", Filters: []query.GridFilter{{Field: "tahun_ajaran", Operator: "eq", Value: "2024"}}},
+			}},
+			expectedFilter: query.GridFilterMain{Logic: "or", Filters: []query.GridFilter{
+				nama,
+				{HasSubFilter: true, Logic: "and", Filters: []query.GridFilter{{Field: "tahun_ajaran", Operator: "eq", Value: "2024"}}},
+			}},
+		},
+		{
+			scenarioName: "scope is and with filters of request",
+			ctx:          WithSemester(context.Background(), active, ""),
+			filter:       query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{nama, tingkat}},
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{
+				nama,
+				tingkat,
+				{Field: "tahun_ajaran", Operator: "eq", Value: "2026"},
+			}},
+		},
+		{
+			scenarioName: "or filters of request are nested under scope",
+			ctx:          WithSemester(context.Background(), active, ""),
+			filter:       query.GridFilterMain{Logic: "or", Filters: []query.GridFilter{nama, tingkat}},
+			expectedFilter: query.GridFilterMain{Logic: "and", Filters: []query.GridFilter{
+				{HasSubFilter: true, Logic: "or", Filters: []query.GridFilter{nama, tingkat}},
+				{Field: "tahun_ajaran", Operator: "eq", Value: "2026"},
+			}},
+		},
+		{
+			scenarioName: "error of resolver is returned",
+			ctx:          WithSemester(context.Background(), failed, "3"),
+			expectedErr:  true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			gridParams := &query.GridParams{Filter: v.filter, HasFilter: len(v.filter.Filters) > 0}
+
+			err := Scope(v.ctx, gridParams, "tahun_ajaran")
+			if v.expectedErr != (err != nil) {
+				t.Errorf("expect error %t, but got %v", v.expectedErr, err)
+				return
+			}
+			if v.expectedErr {
+				return
+			}
+
+			if !reflect.DeepEqual(gridParams.Filter, v.expectedFilter) {
+				t.Errorf("expect filter %+v, but got %+v", v.expectedFilter, gridParams.Filter)
+				return
+			}
+			if gridParams.HasFilter != (len(v.expectedFilter.Filters) > 0) {
+				t.Errorf("expect has filter %t, but got %t", len(v.expectedFilter.Filters) > 0, gridParams.HasFilter)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package precondition implements optimistic concurrency with HTTP conditional requests. ETag of a
+// row is a hash of its response, so it changes with derived fields that are selected from other
+// tables as well as with updated_at of the row. GET responds it in ETag header, and 304 Not Modified when
+// If-None-Match has it. Update and delete carry If-Match in context to the service, which compares
+// it with the row it locked, and fails with 412 Precondition Failed when the row has changed since
+// client read it.
+//
+// Usage in service, inside transaction of update:
+//
+//	err = precondition.Check(ctx, "updatesiswa", "Siswa with id: "+id, precondition.ETag(siswa))
+package precondition
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+// Headers of conditional requests
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+type conditionKey struct{}
+
+// condition is If-Match of request
+type condition struct {
+	ifMatch  string
+	required bool
+}
+
+// WithIfMatch returns ctx that carries If-Match header of request. When required is true, Check
+// fails for request without If-Match.
+func WithIfMatch(ctx context.Context, ifMatch string, required bool) context.Context {
+	return context.WithValue(ctx, conditionKey{}, condition{ifMatch: ifMatch, required: required})
+}
+
+// ETag returns strong entity tag of row, a hash of its JSON as it is responded. It is empty when
+// row is nil or can not be marshalled.
+func ETag(row interface{}) string {
+	if row == nil {
+		return ""
+	}
+
+	v := reflect.ValueOf(row)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+
+	b, err := json.Marshal(row)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(b)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:12]) + `"`
+}
+
+// Match returns true when header, a list of entity tags or *, has etag. Weak tags match as well,
+// as If-None-Match compares them.
+func Match(header string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check compares If-Match that ctx carries with etag of row, see ETag. Row must be locked until it
+// is written, e.g. by SELECT ... FOR UPDATE. It returns 412 when row has changed, and 428 when
+// If-Match is required but not sent. Fn names wrapped error, resource names row in message.
+func Check(ctx context.Context, fn string, resource string, etag string) error {
+	c, ok := ctx.Value(conditionKey{}).(condition)
+	if !ok {
+		return nil
+	}
+
+	if c.ifMatch == "" {
+		if c.required {
+			return apierror.NewError(http.StatusPreconditionRequired, apierror.PreconditionRequired, "If-Match header is not set. Get "+resource+" and send its ETag in If-Match", errors.New(fn+": if-match is not set"))
+		}
+		return nil
+	}
+
+	// strong comparison, weak tags never match If-Match
+	for _, tag := range strings.Split(c.ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || (tag == etag && etag != "") {
+			return nil
+		}
+	}
+
+	return apierror.NewError(http.StatusPreconditionFailed, apierror.PreconditionFailed, resource+" has changed since it was read. Get it again and retry", errors.New(fn+": etag "+etag+" does not match if-match "+c.ifMatch))
+}
@@ -0,0 +1,145 @@
+package precondition
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+func TestETag(t *testing.T) {
+	type row struct {
+		ID          string `json:"id"`
+		IDWaliKelas *int   `json:"id_wali_kelas"`
+	}
+
+	waliKelas := 2
+	unassigned := row{ID: "1"}
+	assigned := row{ID: "1", IDWaliKelas: &waliKelas}
+
+	if ETag(nil) != "" || ETag((*row)(nil)) != "" {
+		t.Errorf("expect no etag without row, but got %s", ETag(nil))
+		return
+	}
+
+	if ETag(unassigned) != ETag(&row{ID: "1"}) {
+		t.Errorf("expect same etag of same row %s, but got %s", ETag(unassigned), ETag(&row{ID: "1"}))
+		return
+	}
+
+	if ETag(unassigned) == ETag(assigned) {
+		t.Errorf("expect etag to change with derived field, but got %s", ETag(assigned))
+		return
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		header       string
+		etag         string
+		expected     bool
+	}{
+		{scenarioName: "same tag", header: `"abc"`, etag: `"abc"`, expected: true},
+		{scenarioName: "other tag", header: `"abd"`, etag: `"abc"`, expected: false},
+		{scenarioName: "tag in list", header: `"abd", "abc"`, etag: `"abc"`, expected: true},
+		{scenarioName: "weak tag", header: `W/"abc"`, etag: `"abc"`, expected: true},
+		{scenarioName: "any tag", header: `*`, etag: `"abc"`, expected: true},
+		{scenarioName: "no etag", header: `*`, etag: "", expected: false},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			match := Match(v.header, v.etag)
+			if match != v.expected {
+				t.Errorf("expect match %t, but got %t", v.expected, match)
+				return
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	etag := ETag(map[string]string{"id": "1", "updated_at": "2019-07-01T08:00:00Z"})
+	stale := ETag(map[string]string{"id": "1", "updated_at": "2019-07-01T07:59:59Z"})
+
+	testScenarios := []struct {
+		scenarioName    string
+		ctx             context.Context
+		etag            string
+		expectedStatus  int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "request without condition",
+			ctx:          context.Background(),
+			etag:         etag,
+		},
+		{
+			scenarioName: "if-match is not sent",
+			ctx:          WithIfMatch(context.Background(), "", false),
+			etag:         etag,
+		},
+		{
+			scenarioName:    "if-match is required but not sent",
+			ctx:             WithIfMatch(context.Background(), "", true),
+			etag:            etag,
+			expectedStatus:  http.StatusPreconditionRequired,
+			expectedErrCode: apierror.PreconditionRequired,
+		},
+		{
+			scenarioName: "if-match has etag of row",
+			ctx:          WithIfMatch(context.Background(), etag, true),
+			etag:         etag,
+		},
+		{
+			scenarioName: "if-match is any",
+			ctx:          WithIfMatch(context.Background(), "*", true),
+			etag:         etag,
+		},
+		{
+			scenarioName:    "row has changed",
+			ctx:             WithIfMatch(context.Background(), stale, false),
+			etag:            etag,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedErrCode: apierror.PreconditionFailed,
+		},
+		{
+			scenarioName:    "weak tag never matches",
+			ctx:             WithIfMatch(context.Background(), "W/"+etag, false),
+			etag:            etag,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedErrCode: apierror.PreconditionFailed,
+		},
+		{
+			scenarioName:    "row without etag",
+			ctx:             WithIfMatch(context.Background(), `""`, false),
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedErrCode: apierror.PreconditionFailed,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			err := Check(v.ctx, "updatesiswa", "Siswa with id: 1", v.etag)
+
+			if v.expectedErrCode == "" {
+				if err != nil {
+					t.Errorf("expect no error, but got %s", err)
+				}
+				return
+			}
+
+			apiErr, ok := err.(*apierror.APIError)
+			if !ok {
+				t.Errorf("expect API error, but got %v", err)
+				return
+			}
+			if apiErr.Code != v.expectedErrCode || apiErr.HTTPStatus != v.expectedStatus {
+				t.Errorf("expect error %d %s, but got %d %s", v.expectedStatus, v.expectedErrCode, apiErr.HTTPStatus, apiErr.Code)
+				return
+			}
+		})
+	}
+}
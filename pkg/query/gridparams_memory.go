@@ -0,0 +1,305 @@
+package query
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Usage:
+// page, total, err := Apply(rows, gridParams)
+//
+// Apply filters, sorts and pages rows in memory the way FullQuery and FilterQuery do in PostgreSQL.
+// Fields are columns named by db tags of row struct. Like PostgreSQL, NULL matches no comparison,
+// and sorts last ascending and first descending. Strings sort by byte order, not by collation.
+
+// Apply returns page of rows that match filter of l, in sort order of l, and total of matching rows
+func Apply[T any](rows []T, l *GridParams) ([]T, int, error) {
+	columns := columnIndex(reflect.TypeOf(rows).Elem())
+
+	matched := []T{}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		ok := true
+		if l.HasFilter {
+			var err error
+			ok, err = matchFilters(v, columns, l.Filter.Logic, l.Filter.Filters)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+
+	if l.HasSort {
+		for _, s := range l.Sort {
+			if _, ok := columns[s.Field]; !ok {
+				return nil, 0, errors.New("apply: column " + s.Field + " does not exist")
+			}
+		}
+
+		sort.SliceStable(matched, func(i, j int) bool {
+			a, b := reflect.ValueOf(matched[i]), reflect.ValueOf(matched[j])
+			for _, s := range l.Sort {
+				index := columns[s.Field]
+				c := compareValues(a.FieldByIndex(index), b.FieldByIndex(index), strings.ToLower(s.Dir) == "desc")
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+
+	if l.Skip < 0 || l.PageSize < 0 {
+		return nil, 0, errors.New("apply: skip and page size must not be negative")
+	}
+
+	start := l.Skip
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + l.PageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return matched[start:end], len(matched), nil
+}
+
+// columnIndex maps db tag of every field to its index
+func columnIndex(t reflect.Type) map[string][]int {
+	columns := map[string][]int{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("db"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		columns[name] = f.Index
+	}
+
+	return columns
+}
+
+func matchFilters(v reflect.Value, columns map[string][]int, logic string, filters []GridFilter) (bool, error) {
+	or := strings.ToLower(logic) == "or"
+	for _, f := range filters {
+		ok, err := matchFilter(v, columns, f)
+		if err != nil {
+			return false, err
+		}
+		if or && ok {
+			return true, nil
+		}
+		if !or && !ok {
+			return false, nil
+		}
+	}
+
+	// nothing matched or, or everything matched and
+	return !or || len(filters) == 0, nil
+}
+
+func matchFilter(v reflect.Value, columns map[string][]int, f GridFilter) (bool, error) {
+	if f.HasSubFilter {
+		return matchFilters(v, columns, f.Logic, f.Filters)
+	}
+
+	index, ok := columns[f.Field]
+	if !ok {
+		return false, errors.New("matchfilter: column " + f.Field + " does not exist")
+	}
+	operator, ok := pgOperatorMap[f.Operator]
+	if !ok {
+		return false, errors.New("matchfilter: operator " + f.Operator + " is not supported")
+	}
+
+	field := v.FieldByIndex(index)
+	null := field.Kind() == reflect.Ptr && field.IsNil()
+	if field.Kind() == reflect.Ptr && !null {
+		field = field.Elem()
+	}
+
+	switch f.Operator {
+	case "isnull":
+		return null, nil
+	case "isnotnull":
+		return !null, nil
+	}
+
+	if field.Kind() != reflect.String && f.Operator != "eq" && f.Operator != "neq" {
+		return false, errors.New("matchfilter: operator " + f.Operator + " needs text column, " + f.Field + " is not")
+	}
+
+	switch f.Operator {
+	case "isempty":
+		return !null && field.String() == "", nil
+	case "isnotempty":
+		return !null && field.String() != "", nil
+	}
+
+	value, ok := f.Value.(string)
+	if !ok {
+		return false, errors.New("matchfilter: value of " + f.Field + " must be a string")
+	}
+	if null {
+		return false, nil
+	}
+
+	switch f.Operator {
+	case "eq", "neq":
+		c, err := compareText(field, value)
+		if err != nil {
+			return false, errors.Wrap(err, "matchfilter: invalid value of "+f.Field)
+		}
+		return (c == 0) == (f.Operator == "eq"), nil
+	}
+
+	pattern := value
+	if operator.WildcardBefore {
+		pattern = "%" + pattern
+	}
+	if operator.WildcardAfter {
+		pattern += "%"
+	}
+	matched := like(field.String(), pattern)
+	if strings.HasPrefix(operator.Operator, "NOT") {
+		return !matched, nil
+	}
+
+	return matched, nil
+}
+
+// compareText compares field with value parsed as type of field, like PostgreSQL casts text parameter
+func compareText(field reflect.Value, value string) (int, error) {
+	switch field.Kind() {
+	case reflect.String:
+		return strings.Compare(field.String(), value), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(field.Int(), n), nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(field.Float(), n), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return 0, err
+		}
+		return compareValues(field, reflect.ValueOf(b), false), nil
+	}
+
+	if t, ok := field.Interface().(time.Time); ok {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(t.UnixNano(), parsed.UnixNano()), nil
+	}
+
+	return 0, errors.New("comparetext: column type " + field.Type().String() + " is not supported")
+}
+
+// compareValues compares two values of one field. NULL is greater than everything, so it sorts last,
+// and desc reverses order including NULL, like PostgreSQL.
+func compareValues(a reflect.Value, b reflect.Value, desc bool) int {
+	c := 0
+	aNull := a.Kind() == reflect.Ptr && a.IsNil()
+	bNull := b.Kind() == reflect.Ptr && b.IsNil()
+	switch {
+	case aNull && bNull:
+		c = 0
+	case aNull:
+		c = 1
+	case bNull:
+		c = -1
+	default:
+		if a.Kind() == reflect.Ptr {
+			a, b = a.Elem(), b.Elem()
+		}
+		c = compareNotNull(a, b)
+	}
+
+	if desc {
+		return -c
+	}
+
+	return c
+}
+
+func compareNotNull(a reflect.Value, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float())
+	case reflect.Bool:
+		return compareOrdered(boolNumber(a.Bool()), boolNumber(b.Bool()))
+	}
+
+	if t, ok := a.Interface().(time.Time); ok {
+		return compareOrdered(t.UnixNano(), b.Interface().(time.Time).UnixNano())
+	}
+
+	return 0
+}
+
+func compareOrdered[T int64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolNumber(b bool) int64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// like matches s with LIKE pattern, where % is any text, _ is one character and \ escapes them
+func like(s string, pattern string) bool {
+	var expr strings.Builder
+	expr.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(".*")
+		case r == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(s)
+}
@@ -0,0 +1,195 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testRow struct {
+	ID     int     `db:"id"`
+	Nama   string  `db:"nama"`
+	Alamat *string `db:"alamat"`
+	Skip   string
+}
+
+func testRows() []testRow {
+	bandung, jakarta := "Bandung", "Jakarta"
+	return []testRow{
+		{ID: 1, Nama: "Budi", Alamat: &bandung},
+		{ID: 2, Nama: "Ani", Alamat: nil},
+		{ID: 3, Nama: "Citra", Alamat: &jakarta},
+		{ID: 4, Nama: "Bayu", Alamat: &bandung},
+	}
+}
+
+func ids(rows []testRow) []int {
+	result := []int{}
+	for _, r := range rows {
+		result = append(result, r.ID)
+	}
+
+	return result
+}
+
+func TestApply(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName  string
+		gridParams    GridParams
+		expectedIDs   []int
+		expectedTotal int
+		expectedError bool
+	}{
+		{
+			scenarioName:  "no filter and sort keeps insert order",
+			gridParams:    GridParams{PageSize: 10},
+			expectedIDs:   []int{1, 2, 3, 4},
+			expectedTotal: 4,
+		},
+		{
+			scenarioName: "contains",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "nama", Operator: "contains", Value: "u"},
+			}}},
+			expectedIDs:   []int{1, 4},
+			expectedTotal: 2,
+		},
+		{
+			scenarioName: "eq on int column",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "id", Operator: "eq", Value: "3"},
+			}}},
+			expectedIDs:   []int{3},
+			expectedTotal: 1,
+		},
+		{
+			scenarioName: "or logic",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "or", Filters: []GridFilter{
+				{Field: "nama", Operator: "startswith", Value: "A"},
+				{Field: "nama", Operator: "startswith", Value: "C"},
+			}}},
+			expectedIDs:   []int{2, 3},
+			expectedTotal: 2,
+		},
+		{
+			scenarioName: "sub filter",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "alamat", Operator: "eq", Value: "Bandung"},
+				{HasSubFilter: true, Logic: "or", Filters: []GridFilter{
+					{Field: "nama", Operator: "eq", Value: "Bayu"},
+					{Field: "nama", Operator: "eq", Value: "Ani"},
+				}},
+			}}},
+			expectedIDs:   []int{4},
+			expectedTotal: 1,
+		},
+		{
+			scenarioName: "null matches no comparison",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "alamat", Operator: "neq", Value: "Bandung"},
+			}}},
+			expectedIDs:   []int{3},
+			expectedTotal: 1,
+		},
+		{
+			scenarioName: "isnull",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "alamat", Operator: "isnull"},
+			}}},
+			expectedIDs:   []int{2},
+			expectedTotal: 1,
+		},
+		{
+			scenarioName:  "sort asc puts null last",
+			gridParams:    GridParams{PageSize: 10, HasSort: true, Sort: []GridSort{{Field: "alamat", Dir: "asc"}, {Field: "nama", Dir: "asc"}}},
+			expectedIDs:   []int{4, 1, 3, 2},
+			expectedTotal: 4,
+		},
+		{
+			scenarioName:  "sort desc puts null first",
+			gridParams:    GridParams{PageSize: 10, HasSort: true, Sort: []GridSort{{Field: "alamat", Dir: "desc"}, {Field: "nama", Dir: "asc"}}},
+			expectedIDs:   []int{2, 3, 4, 1},
+			expectedTotal: 4,
+		},
+		{
+			scenarioName:  "paging returns total of every matching row",
+			gridParams:    GridParams{Skip: 1, PageSize: 2, HasSort: true, Sort: []GridSort{{Field: "id", Dir: "desc"}}},
+			expectedIDs:   []int{3, 2},
+			expectedTotal: 4,
+		},
+		{
+			scenarioName:  "skip past last row",
+			gridParams:    GridParams{Skip: 10, PageSize: 10},
+			expectedIDs:   []int{},
+			expectedTotal: 4,
+		},
+		{
+			scenarioName: "unknown filter column",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "Skip", Operator: "eq", Value: "x"},
+			}}},
+			expectedError: true,
+		},
+		{
+			scenarioName:  "unknown sort column",
+			gridParams:    GridParams{PageSize: 10, HasSort: true, Sort: []GridSort{{Field: "umur", Dir: "asc"}}},
+			expectedError: true,
+		},
+		{
+			scenarioName: "text operator on int column",
+			gridParams: GridParams{PageSize: 10, HasFilter: true, Filter: GridFilterMain{Logic: "and", Filters: []GridFilter{
+				{Field: "id", Operator: "contains", Value: "1"},
+			}}},
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			page, total, err := Apply(testRows(), &v.gridParams)
+			if (err != nil) != v.expectedError {
+				t.Errorf("expect error %t, but got %v", v.expectedError, err)
+				return
+			}
+			if v.expectedError {
+				return
+			}
+
+			if !reflect.DeepEqual(ids(page), v.expectedIDs) {
+				t.Errorf("expect ids %v, but got %v", v.expectedIDs, ids(page))
+				return
+			}
+
+			if total != v.expectedTotal {
+				t.Errorf("expect total %d, but got %d", v.expectedTotal, total)
+				return
+			}
+		})
+	}
+}
+
+func TestLike(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		s            string
+		pattern      string
+		expected     bool
+	}{
+		{scenarioName: "any text", s: "Budi", pattern: "B%", expected: true},
+		{scenarioName: "one character", s: "Budi", pattern: "B_di", expected: true},
+		{scenarioName: "case sensitive", s: "Budi", pattern: "b%", expected: false},
+		{scenarioName: "escaped percent", s: "100%", pattern: "100\\%", expected: true},
+		{scenarioName: "escaped percent is literal", s: "1000", pattern: "100\\%", expected: false},
+		{scenarioName: "regexp characters are literal", s: "a.c", pattern: "a.c", expected: true},
+		{scenarioName: "regexp dot matches no other character", s: "abc", pattern: "a.c", expected: false},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			matched := like(v.s, v.pattern)
+			if matched != v.expected {
+				t.Errorf("expect %t, but got %t", v.expected, matched)
+				return
+			}
+		})
+	}
+}
@@ -25,7 +25,7 @@ func FilterClause(l GridFilter) (string, []interface{}) {
 		for _, v := range l.Filters {
 			subQuery, subParams := FilterClause(v)
 			query += subQuery
-			params = append(params, subParams)
+			params = append(params, subParams...)
 			if i != len(l.Filters)-1 {
 				query += strings.ToUpper(l.Logic) + " "
 			}
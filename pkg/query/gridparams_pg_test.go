@@ -1,6 +1,7 @@
 package query
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -48,3 +49,29 @@ func TestGridParam_FullQuery_Simple(t *testing.T) {
 		return
 	}
 }
+
+func TestGridParam_FullQuery_SubFilter(t *testing.T) {
+	gridParams := &GridParams{Take: 10, Page: 1, Skip: 0, PageSize: 10, HasFilter: true}
+	gridParams.Filter = GridFilterMain{
+		Logic: "and",
+		Filters: []GridFilter{
+			{
+				HasSubFilter: true,
+				Logic:        "or",
+				Filters: []GridFilter{
+					{Field: "nama", Operator: "eq", Value: "1A"},
+					{Field: "nama", Operator: "eq", Value: "1B"},
+				},
+			},
+			{Field: "tahun_ajaran", Operator: "eq", Value: "2026"},
+		},
+	}
+
+	_, params := FullQuery(gridParams, "", nil)
+
+	expectedParams := []interface{}{"1A", "1B", "2026"}
+	if !reflect.DeepEqual(params, expectedParams) {
+		t.Errorf("expect parameters %v, but got %v", expectedParams, params)
+		return
+	}
+}
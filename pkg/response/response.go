@@ -1,7 +1,11 @@
 package response
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo"
+
+	"github.com/syukur91/ischool-monitor/pkg/precondition"
 )
 
 // Response JSONAPI object
@@ -15,10 +19,11 @@ type Response struct {
 
 // Error object
 type Error struct {
-	Status int    `json:"status,omitempty"`
-	Code   int    `json:"code,omitempty"`
-	Title  string `json:"title,omitempty"`
-	Detail string `json:"detail,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Code   string      `json:"code,omitempty"`
+	Title  string      `json:"title,omitempty"`
+	Detail string      `json:"detail,omitempty"`
+	Meta   interface{} `json:"meta,omitempty"`
 }
 
 // JSON is
@@ -28,6 +33,25 @@ func JSON(c echo.Context, status int, data interface{}) error {
 	return c.JSON(status, r)
 }
 
+// JSONWithETag is JSON with etag in ETag header. GET and HEAD respond 304 Not Modified without body
+// when If-None-Match has etag. Empty etag is not sent.
+func JSONWithETag(c echo.Context, status int, data interface{}, etag string) error {
+	if etag == "" {
+		return JSON(c, status, data)
+	}
+
+	c.Response().Header().Set(precondition.HeaderETag, etag)
+
+	req := c.Request()
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if precondition.Match(req.Header.Get(precondition.HeaderIfNoneMatch), etag) {
+			return c.NoContent(http.StatusNotModified)
+		}
+	}
+
+	return JSON(c, status, data)
+}
+
 // JSONGrid is
 func JSONGrid(c echo.Context, status int, data interface{}, length int, count int) error {
 	r := struct {
@@ -0,0 +1,61 @@
+package seed
+
+// Word lists of demo data. Order matters: changing it changes data of every seed.
+
+var firstNames = []string{
+	"Adi", "Agus", "Ahmad", "Aisyah", "Alya", "Andi", "Anisa", "Arief", "Ayu", "Bagas",
+	"Bayu", "Budi", "Cahya", "Citra", "Dani", "Desi", "Dewi", "Dimas", "Eka", "Fajar",
+	"Fauzan", "Fitri", "Galih", "Gita", "Hana", "Hendra", "Ikhsan", "Indah", "Intan", "Joko",
+	"Kartika", "Kevin", "Lestari", "Lina", "Made", "Maya", "Nabila", "Nanda", "Nur", "Putri",
+	"Raditya", "Rahmat", "Rina", "Rizky", "Sari", "Sekar", "Siti", "Surya", "Tiara", "Wahyu",
+	"Wulan", "Yoga", "Yusuf", "Zahra",
+}
+
+var lastNames = []string{
+	"Pratama", "Saputra", "Wijaya", "Hidayat", "Kusuma", "Nugroho", "Santoso", "Setiawan", "Siregar", "Nasution",
+	"Simanjuntak", "Harahap", "Lubis", "Sitompul", "Wibowo", "Purnomo", "Susanto", "Hakim", "Rahman", "Ramadhan",
+	"Permana", "Gunawan", "Halim", "Utomo", "Firmansyah", "Maulana", "Putra", "Lestari", "Handayani", "Wulandari",
+	"Sembiring", "Ginting", "Tarigan", "Manurung", "Pohan", "Sihombing", "Rangkuti", "Daulay", "Fauzi", "Syahputra",
+}
+
+var streets = []string{
+	"Jl. Merdeka", "Jl. Sudirman", "Jl. Diponegoro", "Jl. Gajah Mada", "Jl. Ahmad Yani", "Jl. Pahlawan",
+	"Jl. Veteran", "Jl. Kartini", "Jl. Cendrawasih", "Jl. Melati", "Jl. Kenanga", "Jl. Mawar",
+	"Jl. Anggrek", "Jl. Flamboyan", "Jl. Teuku Umar", "Jl. Imam Bonjol", "Jl. Hasanuddin", "Jl. Pattimura",
+}
+
+var cities = []string{
+	"Jakarta Selatan", "Bandung", "Surabaya", "Yogyakarta", "Semarang", "Medan",
+	"Makassar", "Malang", "Depok", "Bogor", "Bekasi", "Tangerang",
+}
+
+// mobilePrefixes are Indonesian mobile operator prefixes
+var mobilePrefixes = []string{"0811", "0812", "0813", "0821", "0822", "0852", "0856", "0857", "0878", "0896"}
+
+// subject is a mata pelajaran taught at every tingkat
+type subject struct {
+	kode string
+	nama string
+}
+
+// subjects of Kurikulum 2013 sekolah dasar
+var subjects = []subject{
+	{kode: "PAI", nama: "Pendidikan Agama dan Budi Pekerti"},
+	{kode: "PPKN", nama: "Pendidikan Pancasila dan Kewarganegaraan"},
+	{kode: "BIND", nama: "Bahasa Indonesia"},
+	{kode: "MTK", nama: "Matematika"},
+	{kode: "IPA", nama: "Ilmu Pengetahuan Alam"},
+	{kode: "IPS", nama: "Ilmu Pengetahuan Sosial"},
+	{kode: "SBDP", nama: "Seni Budaya dan Prakarya"},
+	{kode: "PJOK", nama: "Pendidikan Jasmani, Olahraga dan Kesehatan"},
+	{kode: "BING", nama: "Bahasa Inggris"},
+	{kode: "MULOK", nama: "Muatan Lokal Bahasa Daerah"},
+}
+
+// periods are lesson hours of a school day, in minutes since midnight
+var periods = [][2]int{
+	{7*60 + 0, 8*60 + 10},
+	{8*60 + 10, 9*60 + 20},
+	{9*60 + 40, 10*60 + 50},
+	{10*60 + 50, 12*60 + 0},
+}
@@ -0,0 +1,277 @@
+// Package seed generates a demo school with realistic Indonesian data. Data only depends on
+// Config, so the same seed value always gives the same school.
+package seed
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// WIB is Western Indonesia time zone that demo school is in
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+// Config of demo school
+type Config struct {
+	// Seed of random data. Default 1
+	Seed int64
+
+	// Tenant is mixed into seed, so tenants get different schools from the same seed
+	Tenant string
+
+	// Tingkat is number of grades, starting from 1. Default 6
+	Tingkat int
+
+	// KelasPerTingkat is number of parallel classes, named A, B, ... Default 2
+	KelasPerTingkat int
+
+	// SiswaPerKelas default 20
+	SiswaPerKelas int
+
+	// From is first day of attendance. Default Monday of current week
+	From time.Time
+
+	// Days of attendance, weekends are skipped. Default 5, a school week
+	Days int
+}
+
+// Kelas is a class with its wali kelas, which is at the same index of Dataset.WaliKelas
+type Kelas struct {
+	Nama    string
+	Tingkat int
+}
+
+// WaliKelas is homeroom teacher of a kelas
+type WaliKelas struct {
+	Nama   string
+	Alamat string
+	Telpon string
+}
+
+// MataPelajaran is a subject of a tingkat
+type MataPelajaran struct {
+	Nama    string
+	Kode    string
+	Tingkat int
+}
+
+// Siswa is a student. Kelas indexes Dataset.Kelas
+type Siswa struct {
+	Nama    string
+	Kelas   int
+	Tingkat int
+	Alamat  string
+}
+
+// User is a parent account. Siswa indexes Dataset.Siswa, siblings share a parent
+type User struct {
+	Nama     string
+	Alamat   string
+	Password string
+	Telepon  string
+	Siswa    []int
+}
+
+// JamPelajaran is a lesson. MataPelajaran indexes Dataset.MataPelajaran
+type JamPelajaran struct {
+	MataPelajaran int
+	Mulai         time.Time
+	Akhir         time.Time
+}
+
+// Absence is an attendance record. Schema only records absence: sakit, izin or alfa
+type Absence struct {
+	JamPelajaran int
+	Siswa        int
+	Status       string
+}
+
+// Dataset is a generated demo school
+type Dataset struct {
+	Kelas         []Kelas
+	WaliKelas     []WaliKelas
+	MataPelajaran []MataPelajaran
+	Siswa         []Siswa
+	Users         []User
+	JamPelajaran  []JamPelajaran
+	Absences      []Absence
+}
+
+// DemoPassword is password of every demo parent account
+const DemoPassword = "rahasia123"
+
+// Defaults fills zero fields of config
+func (c *Config) Defaults() {
+	if c.Seed == 0 {
+		c.Seed = 1
+	}
+	if c.Tingkat <= 0 {
+		c.Tingkat = 6
+	}
+	if c.KelasPerTingkat <= 0 {
+		c.KelasPerTingkat = 2
+	}
+	if c.SiswaPerKelas <= 0 {
+		c.SiswaPerKelas = 20
+	}
+	if c.Days <= 0 {
+		c.Days = 5
+	}
+	if c.From.IsZero() {
+		c.From = Monday(time.Now())
+	}
+}
+
+// Monday returns start of Monday of the week of t, in WIB
+func Monday(t time.Time) time.Time {
+	t = t.In(WIB)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, WIB)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Generate returns demo school of config. Zero fields get defaults.
+func Generate(config Config) *Dataset {
+	config.Defaults()
+
+	h := fnv.New64a()
+	h.Write([]byte(config.Tenant))
+	r := rand.New(rand.NewSource(config.Seed ^ int64(h.Sum64())))
+
+	d := &Dataset{}
+	for tingkat := 1; tingkat <= config.Tingkat; tingkat++ {
+		for _, s := range subjects {
+			d.MataPelajaran = append(d.MataPelajaran, MataPelajaran{
+				Nama:    s.nama,
+				Kode:    fmt.Sprintf("%s-%d", s.kode, tingkat),
+				Tingkat: tingkat,
+			})
+		}
+
+		for k := 0; k < config.KelasPerTingkat; k++ {
+			d.Kelas = append(d.Kelas, Kelas{
+				Nama:    fmt.Sprintf("%d%c", tingkat, 'A'+k%26),
+				Tingkat: tingkat,
+			})
+
+			// teachers are addressed by title
+			title := "Bapak"
+			if r.Intn(3) > 0 {
+				title = "Ibu"
+			}
+			d.WaliKelas = append(d.WaliKelas, WaliKelas{
+				Nama:   title + " " + fullName(r),
+				Alamat: address(r),
+				Telpon: phone(r),
+			})
+		}
+	}
+
+	for k, kelas := range d.Kelas {
+		for i := 0; i < config.SiswaPerKelas; i++ {
+			s := Siswa{
+				Nama:    fullName(r),
+				Kelas:   k,
+				Tingkat: kelas.Tingkat,
+				Alamat:  address(r),
+			}
+
+			// about one in eight siswa has an older sibling already enrolled, sharing parent and address
+			if len(d.Users) > 0 && r.Intn(8) == 0 {
+				parent := &d.Users[r.Intn(len(d.Users))]
+				s.Alamat = parent.Alamat
+				s.Nama = strings.Fields(s.Nama)[0] + " " + lastName(parent.Nama)
+				d.Siswa = append(d.Siswa, s)
+				parent.Siswa = append(parent.Siswa, len(d.Siswa)-1)
+				continue
+			}
+
+			d.Siswa = append(d.Siswa, s)
+			title := "Bapak"
+			if r.Intn(2) == 0 {
+				title = "Ibu"
+			}
+			d.Users = append(d.Users, User{
+				Nama:     title + " " + firstNames[r.Intn(len(firstNames))] + " " + lastName(s.Nama),
+				Alamat:   s.Alamat,
+				Password: DemoPassword,
+				Telepon:  phone(r),
+				Siswa:    []int{len(d.Siswa) - 1},
+			})
+		}
+	}
+
+	d.generateAttendance(r, config)
+
+	return d
+}
+
+// generateAttendance schedules lessons of every tingkat on school days, and absences of their siswa
+func (d *Dataset) generateAttendance(r *rand.Rand, config Config) {
+	siswaByTingkat := map[int][]int{}
+	for i, s := range d.Siswa {
+		siswaByTingkat[s.Tingkat] = append(siswaByTingkat[s.Tingkat], i)
+	}
+
+	day := config.From
+	for n := 0; n < config.Days; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		n++
+
+		for tingkat := 1; tingkat <= config.Tingkat; tingkat++ {
+			first := (tingkat - 1) * len(subjects)
+			for _, p := range periods {
+				d.JamPelajaran = append(d.JamPelajaran, JamPelajaran{
+					MataPelajaran: first + r.Intn(len(subjects)),
+					Mulai:         day.Add(time.Duration(p[0]) * time.Minute),
+					Akhir:         day.Add(time.Duration(p[1]) * time.Minute),
+				})
+
+				for _, s := range siswaByTingkat[tingkat] {
+					// about 3% of siswa miss a lesson
+					if r.Intn(100) >= 3 {
+						continue
+					}
+					d.Absences = append(d.Absences, Absence{
+						JamPelajaran: len(d.JamPelajaran) - 1,
+						Siswa:        s,
+						Status:       absenceStatus(r),
+					})
+				}
+			}
+		}
+	}
+}
+
+func absenceStatus(r *rand.Rand) string {
+	switch n := r.Intn(10); {
+	case n < 5:
+		return "sakit"
+	case n < 8:
+		return "izin"
+	default:
+		return "alfa"
+	}
+}
+
+func fullName(r *rand.Rand) string {
+	return firstNames[r.Intn(len(firstNames))] + " " + lastNames[r.Intn(len(lastNames))]
+}
+
+func lastName(name string) string {
+	fields := strings.Fields(name)
+	return fields[len(fields)-1]
+}
+
+func address(r *rand.Rand) string {
+	return fmt.Sprintf("%s No. %d, RT %02d/RW %02d, %s",
+		streets[r.Intn(len(streets))], 1+r.Intn(150), 1+r.Intn(12), 1+r.Intn(9), cities[r.Intn(len(cities))])
+}
+
+func phone(r *rand.Rand) string {
+	return fmt.Sprintf("%s-%04d-%04d", mobilePrefixes[r.Intn(len(mobilePrefixes))], r.Intn(10000), r.Intn(10000))
+}
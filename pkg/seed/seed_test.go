@@ -0,0 +1,166 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testMonday = time.Date(2019, 7, 15, 0, 0, 0, 0, WIB)
+
+func TestGenerate_Deterministic(t *testing.T) {
+	base := Config{Seed: 7, Tenant: "sdn-1", From: testMonday}
+
+	testScenarios := []struct {
+		scenarioName  string
+		config        Config
+		expectedEqual bool
+	}{
+		{
+			scenarioName:  "same seed and tenant",
+			config:        base,
+			expectedEqual: true,
+		},
+		{
+			scenarioName:  "different seed",
+			config:        Config{Seed: 8, Tenant: "sdn-1", From: testMonday},
+			expectedEqual: false,
+		},
+		{
+			scenarioName:  "different tenant",
+			config:        Config{Seed: 7, Tenant: "sdn-2", From: testMonday},
+			expectedEqual: false,
+		},
+	}
+
+	expected := Generate(base)
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			equal := reflect.DeepEqual(expected, Generate(v.config))
+			if equal != v.expectedEqual {
+				t.Errorf("expect equal %t, but got %t", v.expectedEqual, equal)
+				return
+			}
+		})
+	}
+}
+
+func TestGenerate_Counts(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName          string
+		config                Config
+		expectedKelas         int
+		expectedMataPelajaran int
+		expectedSiswa         int
+		expectedJamPelajaran  int
+	}{
+		{
+			scenarioName:          "defaults",
+			config:                Config{From: testMonday},
+			expectedKelas:         12,
+			expectedMataPelajaran: 60,
+			expectedSiswa:         240,
+			expectedJamPelajaran:  120,
+		},
+		{
+			scenarioName:          "small school",
+			config:                Config{Tingkat: 2, KelasPerTingkat: 3, SiswaPerKelas: 4, Days: 2, From: testMonday},
+			expectedKelas:         6,
+			expectedMataPelajaran: 20,
+			expectedSiswa:         24,
+			expectedJamPelajaran:  16,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			d := Generate(v.config)
+			if len(d.Kelas) != v.expectedKelas || len(d.WaliKelas) != v.expectedKelas {
+				t.Errorf("expect %d kelas and wali kelas, but got %d and %d", v.expectedKelas, len(d.Kelas), len(d.WaliKelas))
+				return
+			}
+			if len(d.MataPelajaran) != v.expectedMataPelajaran {
+				t.Errorf("expect %d mata pelajaran, but got %d", v.expectedMataPelajaran, len(d.MataPelajaran))
+				return
+			}
+			if len(d.Siswa) != v.expectedSiswa {
+				t.Errorf("expect %d siswa, but got %d", v.expectedSiswa, len(d.Siswa))
+				return
+			}
+			if len(d.JamPelajaran) != v.expectedJamPelajaran {
+				t.Errorf("expect %d jam pelajaran, but got %d", v.expectedJamPelajaran, len(d.JamPelajaran))
+				return
+			}
+
+			// every siswa has exactly one parent
+			parents := map[int]int{}
+			for _, u := range d.Users {
+				for _, s := range u.Siswa {
+					parents[s]++
+				}
+			}
+			for i := range d.Siswa {
+				if parents[i] != 1 {
+					t.Errorf("expect siswa %d to have one parent, but got %d", i, parents[i])
+					return
+				}
+			}
+
+			for _, s := range d.Siswa {
+				if d.Kelas[s.Kelas].Tingkat != s.Tingkat {
+					t.Errorf("expect siswa %s in kelas of tingkat %d, but got kelas %s", s.Nama, s.Tingkat, d.Kelas[s.Kelas].Nama)
+					return
+				}
+			}
+
+			for _, a := range d.Absences {
+				jam := d.JamPelajaran[a.JamPelajaran]
+				if d.MataPelajaran[jam.MataPelajaran].Tingkat != d.Siswa[a.Siswa].Tingkat {
+					t.Errorf("expect absence in lesson of siswa tingkat")
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_SkipsWeekends(t *testing.T) {
+	// starting on Friday, 3 school days are Friday, Monday and Tuesday
+	d := Generate(Config{Tingkat: 1, KelasPerTingkat: 1, SiswaPerKelas: 1, Days: 3, From: testMonday.AddDate(0, 0, 4)})
+
+	days := map[string]bool{}
+	for _, j := range d.JamPelajaran {
+		if j.Mulai.Weekday() == time.Saturday || j.Mulai.Weekday() == time.Sunday {
+			t.Errorf("expect no lesson on weekend, but got %s", j.Mulai)
+			return
+		}
+		days[j.Mulai.Format("2006-01-02")] = true
+	}
+
+	expected := map[string]bool{"2019-07-19": true, "2019-07-22": true, "2019-07-23": true}
+	if !reflect.DeepEqual(days, expected) {
+		t.Errorf("expect days %v, but got %v", expected, days)
+		return
+	}
+}
+
+func TestMonday(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		t            time.Time
+	}{
+		{scenarioName: "monday", t: testMonday.Add(9 * time.Hour)},
+		{scenarioName: "sunday", t: testMonday.AddDate(0, 0, 6).Add(23 * time.Hour)},
+		{scenarioName: "utc evening before monday in WIB", t: time.Date(2019, 7, 14, 20, 0, 0, 0, time.UTC)},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			got := Monday(v.t)
+			if !got.Equal(testMonday) {
+				t.Errorf("expect %s, but got %s", testMonday, got)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// tables filled by Write, referencing tables last
+var tables = []string{
+	"public.jam_pelajaran_siswa",
+	"public.jam_pelajaran",
+	"public.user_siswa",
+	"public.user",
+	"public.siswa",
+	"public.kelas_wali_kelas",
+	"public.wali_kelas",
+	"public.kelas",
+	"public.mata_pelajaran",
+}
+
+// Counts is number of rows written per table
+type Counts struct {
+	Kelas         int
+	WaliKelas     int
+	MataPelajaran int
+	Siswa         int
+	Users         int
+	JamPelajaran  int
+	Absences      int
+}
+
+// Write inserts dataset in one transaction. It refuses to write into a database that already
+// has school data, unless reset is true, which empties the tables first.
+func Write(ctx context.Context, db *sqlx.DB, d *Dataset, reset bool) (Counts, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return Counts{}, errors.Wrap(err, "write: begin transaction failed")
+	}
+
+	err = write(ctx, tx, d, reset)
+	if err != nil {
+		tx.Rollback()
+		return Counts{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Counts{}, errors.Wrap(err, "write: commit transaction failed")
+	}
+
+	return Counts{
+		Kelas:         len(d.Kelas),
+		WaliKelas:     len(d.WaliKelas),
+		MataPelajaran: len(d.MataPelajaran),
+		Siswa:         len(d.Siswa),
+		Users:         len(d.Users),
+		JamPelajaran:  len(d.JamPelajaran),
+		Absences:      len(d.Absences),
+	}, nil
+}
+
+func write(ctx context.Context, tx *sqlx.Tx, d *Dataset, reset bool) error {
+	if reset {
+		_, err := tx.ExecContext(ctx, "TRUNCATE "+joinTables()+" RESTART IDENTITY CASCADE")
+		if err != nil {
+			return errors.Wrap(err, "write: empty tables failed")
+		}
+	} else {
+		for _, table := range tables {
+			exists := false
+			err := tx.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM "+table+")")
+			if err != nil {
+				return errors.Wrap(err, "write: check "+table+" failed")
+			}
+			if exists {
+				return errors.New("write: " + table + " already has data. Reset to replace it with demo data")
+			}
+		}
+	}
+
+	kelasIDs := make([]int, len(d.Kelas))
+	waliKelasIDs := make([]int, len(d.WaliKelas))
+	for i, k := range d.Kelas {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.kelas (nama, tingkat, tahun_ajaran) VALUES ($1, $2, public.tahun_ajaran(current_date)) RETURNING id`,
+			k.Nama, k.Tingkat).Scan(&kelasIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: insert kelas "+k.Nama+" failed")
+		}
+
+		w := d.WaliKelas[i]
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO public.wali_kelas (nama, alamat, telpon) VALUES ($1, $2, $3) RETURNING id`,
+			w.Nama, w.Alamat, w.Telpon).Scan(&waliKelasIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: insert wali kelas "+w.Nama+" failed")
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO public.kelas_wali_kelas (id_kelas, id_wali_kelas, tahun_ajaran) VALUES ($1, $2, public.tahun_ajaran(current_date))`,
+			kelasIDs[i], waliKelasIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: assign wali kelas "+w.Nama+" to kelas "+k.Nama+" failed")
+		}
+	}
+
+	mataPelajaranIDs := make([]int, len(d.MataPelajaran))
+	for i, m := range d.MataPelajaran {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.mata_pelajaran (nama, kode, tingkat) VALUES ($1, $2, $3) RETURNING id`,
+			m.Nama, m.Kode, m.Tingkat).Scan(&mataPelajaranIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: insert mata pelajaran "+m.Kode+" failed")
+		}
+	}
+
+	siswaIDs := make([]int, len(d.Siswa))
+	for i, s := range d.Siswa {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.siswa (nama, id_kelas, tingkat, alamat) VALUES ($1, $2, $3, $4) RETURNING id`,
+			s.Nama, kelasIDs[s.Kelas], s.Tingkat, s.Alamat).Scan(&siswaIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: insert siswa "+s.Nama+" failed")
+		}
+	}
+
+	for _, u := range d.Users {
+		userID := 0
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.user (nama, alamat, password, telepon) VALUES ($1, $2, $3, $4) RETURNING id`,
+			u.Nama, u.Alamat, u.Password, u.Telepon).Scan(&userID)
+		if err != nil {
+			return errors.Wrap(err, "write: insert user "+u.Nama+" failed")
+		}
+
+		for _, s := range u.Siswa {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO public.user_siswa (id_user, id_siswa) VALUES ($1, $2)`,
+				userID, siswaIDs[s])
+			if err != nil {
+				return errors.Wrap(err, "write: insert user siswa of "+u.Nama+" failed")
+			}
+		}
+	}
+
+	jamPelajaranIDs := make([]int, len(d.JamPelajaran))
+	for i, j := range d.JamPelajaran {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO public.jam_pelajaran (id_matpel, jam_mulai, jam_akhir) VALUES ($1, $2, $3) RETURNING id`,
+			mataPelajaranIDs[j.MataPelajaran], j.Mulai, j.Akhir).Scan(&jamPelajaranIDs[i])
+		if err != nil {
+			return errors.Wrap(err, "write: insert jam pelajaran failed")
+		}
+	}
+
+	// absence is recorded when lesson starts, so attendance metrics see it on that day
+	for _, a := range d.Absences {
+		recordedAt := d.JamPelajaran[a.JamPelajaran].Mulai
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO public.jam_pelajaran_siswa (id_jam_pelajaran, id_siswa, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)`,
+			jamPelajaranIDs[a.JamPelajaran], siswaIDs[a.Siswa], a.Status, recordedAt)
+		if err != nil {
+			return errors.Wrap(err, "write: insert attendance failed")
+		}
+	}
+
+	return nil
+}
+
+func joinTables() string {
+	joined := ""
+	for i, table := range tables {
+		if i > 0 {
+			joined += ", "
+		}
+		joined += table
+	}
+
+	return joined
+}
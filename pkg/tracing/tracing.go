@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/query"
+)
+
+// TracerName is instrumentation name of every span created by this app
+const TracerName = "github.com/syukur91/ischool-monitor"
+
+// Exporter names
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+type contextKey int
+
+const tenantKey contextKey = iota
+
+// Config defines how spans are sampled and exported
+type Config struct {
+	ServiceName string
+	Version     string
+
+	// Exporter is one of none, otlp, stdout or file. OTLP exporter is configured
+	// with standard OTEL_EXPORTER_OTLP_* environment variables.
+	Exporter string
+
+	// FilePath is where file exporter writes spans
+	FilePath string
+
+	// SampleRatio is fraction of new traces that are sampled. Traces started
+	// by upstream services follow their sampling decision.
+	SampleRatio float64
+}
+
+// Setup installs global tracer provider and W3C trace context propagator.
+// Call returned shutdown function before exit to flush pending spans.
+func Setup(config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	var err error
+
+	switch config.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(context.Background())
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		f, ferr := os.OpenFile(config.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if ferr != nil {
+			return nil, errors.Wrap(ferr, "tracing: open trace file failed")
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, errors.New("tracing: unknown exporter " + config.Exporter)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "tracing: create exporter failed")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.Version),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "tracing: create resource failed")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// WithTenant returns a copy of ctx that carries tenant. Every span started
+// from ctx is labelled with it.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// Start starts a span named name as child of span in ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if tenant, ok := ctx.Value(tenantKey).(string); ok {
+		opts = append(opts, trace.WithAttributes(attribute.String("tenant", tenant)))
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End marks span as failed if err is not nil and ends it.
+// Use it with named error result: defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		if ae, ok := err.(*apierror.APIError); ok {
+			span.SetAttributes(attribute.String("error.code", string(ae.Code)))
+			if ae.Err != nil {
+				err = ae.Err
+			}
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// GridAttributes describes grid paging, sort and filter so slow grid queries can be found
+func GridAttributes(g *query.GridParams) trace.SpanStartEventOption {
+	return trace.WithAttributes(
+		attribute.Int("grid.skip", g.Skip),
+		attribute.Int("grid.page_size", g.PageSize),
+		attribute.Int("grid.sorts", len(g.Sort)),
+		attribute.Int("grid.filters", len(g.Filter.Filters)),
+	)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/arifsetiawan/go-common/env"
+	"github.com/jmoiron/sqlx"
+	"github.com/syukur91/ischool-monitor/pkg/seed"
+)
+
+// seedCommand fills database with demo school of a tenant
+func seedCommand(args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	dbConnection := flags.String("db", os.Getenv("DB_CONNECTION_STR"), "database connection string. Default is DB_CONNECTION_STR in environment")
+	tenant := flags.String("tenant", "demo", "tenant of demo school, mixed into seed")
+	seedValue := flags.Int64("seed", 1, "seed of random data. Same seed and tenant give the same school")
+	tingkat := flags.Int("tingkat", 6, "number of tingkat")
+	kelas := flags.Int("kelas", 2, "number of kelas per tingkat")
+	siswa := flags.Int("siswa", 20, "number of siswa per kelas")
+	from := flags.String("from", "", "first day of attendance as YYYY-MM-DD. Default is Monday of current week")
+	days := flags.Int("days", 5, "number of school days of attendance")
+	reset := flags.Bool("reset", false, "empty school tables before seeding")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage+"\nFlags of seed:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *dbConnection == "" {
+		log.Fatalf("Database connection string is not set. Set DB_CONNECTION_STR in environment or pass -db\n")
+	}
+
+	config := seed.Config{
+		Seed:            *seedValue,
+		Tenant:          *tenant,
+		Tingkat:         *tingkat,
+		KelasPerTingkat: *kelas,
+		SiswaPerKelas:   *siswa,
+		Days:            *days,
+	}
+	if *from != "" {
+		day, err := time.ParseInLocation("2006-01-02", *from, seed.WIB)
+		if err != nil {
+			log.Fatalf("First day must be YYYY-MM-DD, got %s\n", *from)
+		}
+		config.From = day
+	}
+
+	db, err := sqlx.Connect(env.Getenv("DB_DRIVER", "postgres"), *dbConnection)
+	if err != nil {
+		log.Fatalf("Failed to make database connection: %v\n", err)
+	}
+	defer db.Close()
+
+	counts, err := seed.Write(context.Background(), db, seed.Generate(config), *reset)
+	if err != nil {
+		log.Fatalf("Failed to seed database: %v\n", err)
+	}
+
+	fmt.Printf("Seeded tenant %s with seed %d: %d kelas, %d wali kelas, %d mata pelajaran, %d siswa, %d users, %d jam pelajaran, %d absences\n",
+		config.Tenant, config.Seed, counts.Kelas, counts.WaliKelas, counts.MataPelajaran, counts.Siswa, counts.Users, counts.JamPelajaran, counts.Absences)
+	fmt.Printf("Parent accounts use password %s\n", seed.DemoPassword)
+}
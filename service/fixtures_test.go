@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+// Factories create valid rows in test database. Modify functions override default values
+// before row is created.
+
+// lastTahunAjaran is tahun ajaran last given to a test by newTahunAjaran. It starts far from real
+// ones, at a point of time, so concurrent runs on one database don't share it either.
+var lastTahunAjaran = 3000 + 2*(time.Now().UnixNano()/int64(time.Millisecond)%10000)
+
+// newTahunAjaran returns tahun ajaran of one test. Rows unique by tahun ajaran, such as semester
+// and kenaikan kelas, of parallel tests then don't wait for each other on unique index. Next tahun
+// ajaran is the test's too, for semester genap or kelas tujuan.
+func newTahunAjaran() int {
+	return int(atomic.AddInt64(&lastTahunAjaran, 2))
+}
+
+// newKelas creates kelas 1A of current tahun ajaran, or kelas modified by modify
+func newKelas(t *testing.T, db *sqlx.DB, modify ...func(request *schema.CreateKelasRequest)) *schema.KelasResponse {
+	t.Helper()
+
+	request := &schema.CreateKelasRequest{
+		Nama:        "1A",
+		Tingkat:     1,
+		TahunAjaran: TahunAjaran(time.Now()),
+	}
+	for _, m := range modify {
+		m(request)
+	}
+
+	kelas, err := NewKelasService(db).CreateKelas(context.Background(), request)
+	if err != nil {
+		t.Fatalf("create kelas %s failed: %s", request.Nama, err)
+	}
+
+	return kelas
+}
+
+// newWaliKelas creates wali kelas, or wali kelas modified by modify
+func newWaliKelas(t *testing.T, db *sqlx.DB, modify ...func(request *schema.CreateWali_KelasRequest)) *schema.Wali_KelasResponse {
+	t.Helper()
+
+	request := &schema.CreateWali_KelasRequest{
+		Nama:   "Ibu Siti Rahman",
+		Alamat: "Jl. Merdeka No. 1, Bandung",
+		Telpon: "0812-0000-0001",
+	}
+	for _, m := range modify {
+		m(request)
+	}
+
+	waliKelas, err := NewWali_KelasService(db).CreateWali_Kelas(context.Background(), request)
+	if err != nil {
+		t.Fatalf("create wali kelas %s failed: %s", request.Nama, err)
+	}
+
+	return waliKelas
+}
+
+// newKelasWaliKelas assigns wali kelas to kelas for tahun ajaran of kelas, or assignment modified
+// by modify
+func newKelasWaliKelas(t *testing.T, db *sqlx.DB, kelas *schema.KelasResponse, idWaliKelas int, modify ...func(request *schema.CreateKelas_Wali_KelasRequest)) *schema.Kelas_Wali_KelasResponse {
+	t.Helper()
+
+	request := &schema.CreateKelas_Wali_KelasRequest{
+		IDKelas:     kelas.ID,
+		IDWaliKelas: idWaliKelas,
+		TahunAjaran: kelas.TahunAjaran,
+	}
+	for _, m := range modify {
+		m(request)
+	}
+
+	assignment, err := NewKelas_Wali_KelasService(db).CreateKelas_Wali_Kelas(context.Background(), request)
+	if err != nil {
+		t.Fatalf("assign wali kelas %d to kelas %d failed: %s", request.IDWaliKelas, request.IDKelas, err)
+	}
+
+	return assignment
+}
+
+// newSiswa creates siswa, or siswa modified by modify. Kelas of siswa tingkat, with wali kelas
+// assigned, is created when modify leaves its id unset.
+func newSiswa(t *testing.T, db *sqlx.DB, modify ...func(request *schema.CreateSiswaRequest)) *schema.SiswaResponse {
+	t.Helper()
+
+	request := &schema.CreateSiswaRequest{
+		Nama:    "Budi Santoso",
+		Tingkat: 1,
+		Alamat:  "Jl. Sudirman No. 2, Bandung",
+	}
+	for _, m := range modify {
+		m(request)
+	}
+
+	if request.IDKelas == 0 {
+		kelas := newKelas(t, db, func(kelas *schema.CreateKelasRequest) { kelas.Tingkat = request.Tingkat })
+		newKelasWaliKelas(t, db, kelas, newWaliKelas(t, db).ID)
+		request.IDKelas = kelas.ID
+	}
+
+	siswa, err := NewSiswaService(db).CreateSiswa(context.Background(), request)
+	if err != nil {
+		t.Fatalf("create siswa %s failed: %s", request.Nama, err)
+	}
+
+	return siswa
+}
+
+// setNonZero returns update field that is set to v, or absent when v is zero, so update scenarios
+// leave out fields they don't change
+func setNonZero[T comparable](v T) optional.Field[T] {
+	var zero T
+	if v == zero {
+		return optional.Field[T]{}
+	}
+
+	return optional.Of(v)
+}
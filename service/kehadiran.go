@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/period"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+// KehadiranRepository ...
+type KehadiranRepository interface {
+	RekapKehadiran(ctx context.Context, p *period.Period) (*schema.RekapKehadiranResponse, error)
+}
+
+var _ KehadiranRepository = (*KehadiranService)(nil)
+
+// KehadiranService reports kehadiran of siswa, written to jam_pelajaran_siswa
+type KehadiranService struct {
+	db *sqlx.DB
+}
+
+// NewKehadiranService ...
+func NewKehadiranService(db *sqlx.DB) *KehadiranService {
+	return &KehadiranService{db: db}
+}
+
+// RekapKehadiran counts kehadiran by status that was written from mulai to akhir of period, like
+// gauge of StatsService. Nil period counts every kehadiran.
+func (s *KehadiranService) RekapKehadiran(ctx context.Context, p *period.Period) (_ *schema.RekapKehadiranResponse, err error) {
+	ctx, span := tracing.Start(ctx, "KehadiranService.RekapKehadiran")
+	defer func() { tracing.End(span, err) }()
+
+	var mulai, akhir *string
+	rekap := &schema.RekapKehadiranResponse{}
+	if p != nil {
+		m, a := p.Mulai.Format("2006-01-02"), p.Akhir.Format("2006-01-02")
+		mulai, akhir = &m, &a
+		rekap.IDSemester, rekap.TahunAjaran, rekap.Mulai, rekap.Akhir = &p.SemesterID, &p.TahunAjaran, &p.Mulai, &p.Akhir
+	}
+
+	err = s.db.GetContext(ctx, rekap, `
+		SELECT
+			count(*) FILTER (WHERE status='sakit') AS sakit,
+			count(*) FILTER (WHERE status='izin') AS izin,
+			count(*) FILTER (WHERE status='alfa') AS alfa
+		FROM public.jam_pelajaran_siswa
+		WHERE deleted_at IS NULL
+			AND ($1::date IS NULL OR (created_at >= $1::date AND created_at < $2::date + 1));`,
+		mulai, akhir)
+	if err != nil {
+		return nil, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "rekapkehadiran: get count failed"))
+	}
+
+	return rekap, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/period"
+)
+
+func TestRekapKehadiran(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKehadiranService(db)
+	tahunAjaran := newTahunAjaran()
+	budi := newSiswa(t, db)
+
+	idMatpel := 0
+	db.Get(&idMatpel, "INSERT INTO public.mata_pelajaran (nama, kode, tingkat) VALUES ('Matematika', $1, 1) RETURNING id", "MTK-"+strconv.Itoa(tahunAjaran))
+	idJamPelajaran := 0
+	db.Get(&idJamPelajaran, "INSERT INTO public.jam_pelajaran (id_matpel) VALUES ($1) RETURNING id", idMatpel)
+
+	// kehadiran on mulai and akhir of ganjil of tahun ajaran of the test, and in genap after it
+	for _, k := range []struct {
+		status    string
+		createdAt time.Time
+	}{
+		{"sakit", time.Date(tahunAjaran, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"alfa", time.Date(tahunAjaran, 12, 31, 12, 0, 0, 0, time.UTC)},
+		{"alfa", time.Date(tahunAjaran+1, 1, 2, 12, 0, 0, 0, time.UTC)},
+	} {
+		db.MustExec("INSERT INTO public.jam_pelajaran_siswa (id_jam_pelajaran, id_siswa, status, created_at) VALUES ($1, $2, $3, $4)",
+			idJamPelajaran, budi.ID, k.status, k.createdAt)
+	}
+
+	ganjil := &period.Period{
+		SemesterID:  1,
+		TahunAjaran: tahunAjaran,
+		Mulai:       time.Date(tahunAjaran, 7, 1, 0, 0, 0, 0, time.UTC),
+		Akhir:       time.Date(tahunAjaran, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	testScenarios := []struct {
+		scenarioName string
+		period       *period.Period
+		expected     schema.RekapKehadiranResponse
+		atLeast      bool
+	}{
+		{
+			scenarioName: "Kehadiran from mulai to akhir of semester",
+			period:       ganjil,
+			expected:     schema.RekapKehadiranResponse{Sakit: 1, Alfa: 1},
+		},
+		{
+			scenarioName: "Kehadiran of every semester",
+			expected:     schema.RekapKehadiranResponse{Sakit: 1, Alfa: 2},
+			atLeast:      true,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			rekap, err := s.RekapKehadiran(context.Background(), v.period)
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			if v.period != nil && (rekap.IDSemester == nil || *rekap.IDSemester != v.period.SemesterID) {
+				t.Errorf("expect semester %d, but got %v", v.period.SemesterID, rekap.IDSemester)
+				return
+			}
+
+			got := []int{rekap.Sakit, rekap.Izin, rekap.Alfa}
+			for i, expected := range []int{v.expected.Sakit, v.expected.Izin, v.expected.Alfa} {
+				if got[i] < expected || (!v.atLeast && got[i] != expected) {
+					t.Errorf("expect sakit, izin and alfa %d %d %d, but got %v", v.expected.Sakit, v.expected.Izin, v.expected.Alfa, got)
+					return
+				}
+			}
+		})
+	}
+}
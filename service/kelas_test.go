@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+func TestUpdateKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKelasService(db)
+	tahunAjaran := newTahunAjaran()
+	kelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = tahunAjaran })
+	newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas.ID })
+	emptyKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = tahunAjaran })
+
+	testScenarios := []struct {
+		scenarioName    string
+		id              int
+		nama            string
+		tingkat         int
+		tahunAjaran     int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "Successful rename kelas with siswa",
+			id:           kelas.ID,
+			nama:         "1B",
+		},
+		{
+			scenarioName:    "Failure update: tingkat of kelas with siswa",
+			id:              kelas.ID,
+			tingkat:         2,
+			expectedErrCode: apierror.KelasHasSiswa,
+		},
+		{
+			scenarioName:    "Failure update: tahun ajaran of kelas with siswa",
+			id:              kelas.ID,
+			tahunAjaran:     tahunAjaran + 1,
+			expectedErrCode: apierror.KelasHasSiswa,
+		},
+		{
+			scenarioName: "Successful update tingkat and tahun ajaran of kelas without siswa",
+			id:           emptyKelas.ID,
+			tingkat:      2,
+			tahunAjaran:  tahunAjaran + 1,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			updatedKelasResponse, err := s.UpdateKelas(context.Background(), strconv.Itoa(v.id), &schema.UpdateKelasRequest{
+				Nama:        setNonZero(v.nama),
+				Tingkat:     setNonZero(v.tingkat),
+				TahunAjaran: setNonZero(v.tahunAjaran),
+			})
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			if err == nil && v.tingkat != 0 && updatedKelasResponse.Tingkat != v.tingkat {
+				t.Errorf("expect tingkat %d, but got %d", v.tingkat, updatedKelasResponse.Tingkat)
+				return
+			}
+		})
+	}
+}
+
+func TestReplaceKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKelasService(db)
+	kelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = newTahunAjaran() })
+	newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas.ID })
+
+	_, err := s.ReplaceKelas(context.Background(), strconv.Itoa(kelas.ID), &schema.CreateKelasRequest{
+		Nama:        kelas.Nama,
+		Tingkat:     kelas.Tingkat + 1,
+		TahunAjaran: kelas.TahunAjaran,
+	})
+	if errorCode(err) != apierror.KelasHasSiswa {
+		t.Errorf("expect error code %s, but got %s", apierror.KelasHasSiswa, errorCode(err))
+		return
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+)
+
+// Hand-written part of Kelas_Wali_KelasService. Generated CRUD lives in kelas_wali_kelas_gen.go,
+// which is overwritten on every generate. This file is only created when missing.
+
+// TahunAjaran returns tahun ajaran of day, as its first year. Tahun ajaran starts in July, so
+// 2026 is 2026/2027. It is what public.tahun_ajaran returns in database.
+func TahunAjaran(day time.Time) int {
+	if day.Month() < time.July {
+		return day.Year() - 1
+	}
+
+	return day.Year()
+}
+
+// validateCreate is called before kelas_wali_kelas is inserted, after required fields are checked
+func (s *Kelas_Wali_KelasService) validateCreate(ctx context.Context, request *schema.CreateKelas_Wali_KelasRequest) error {
+	return s.validateAssignment(ctx, "createkelas_wali_kelas", 0, request.IDKelas, request.IDWaliKelas, request.TahunAjaran)
+}
+
+// validateUpdate is called with existing kelas_wali_kelas merged with update request or replaced by replace
+// request, before it is saved
+func (s *Kelas_Wali_KelasService) validateUpdate(ctx context.Context, kelas_wali_kelas *schema.Kelas_Wali_KelasResponse) error {
+	return s.validateAssignment(ctx, "updatekelas_wali_kelas", kelas_wali_kelas.ID, kelas_wali_kelas.IDKelas, kelas_wali_kelas.IDWaliKelas, kelas_wali_kelas.TahunAjaran)
+}
+
+// validateAssignment checks that kelas and wali kelas exist and are not deleted, that tahun
+// ajaran is the one of kelas, and that kelas has no other wali kelas in tahun ajaran. Kelas is locked until assignment is saved, so
+// concurrent writes can't assign it twice. Id is 0 for assignment that is created.
+func (s *Kelas_Wali_KelasService) validateAssignment(ctx context.Context, fn string, id int, idKelas int, idWaliKelas int, tahunAjaran int) error {
+	return dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		var kelasDeletedAt *time.Time
+		var kelasTahunAjaran int
+		err := tx.QueryRowContext(ctx, `
+			SELECT deleted_at, tahun_ajaran
+			FROM public.kelas
+			WHERE id=$1 FOR UPDATE;`,
+			idKelas).Scan(&kelasDeletedAt, &kelasTahunAjaran)
+
+		if err == sql.ErrNoRows {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasKelasNotFound, "Kelas with id: "+strconv.Itoa(idKelas)+" is not exists", errors.Wrap(err, fn+": kelas is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get kelas failed"))
+		}
+		if kelasDeletedAt != nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasKelasDeleted, "Kelas with id: "+strconv.Itoa(idKelas)+" is deleted. Choose other kelas", errors.New(fn+": kelas is deleted"))
+		}
+		if kelasTahunAjaran != tahunAjaran {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasTahunAjaranMismatch, "Kelas with id: "+strconv.Itoa(idKelas)+" is of tahun ajaran "+strconv.Itoa(kelasTahunAjaran)+", not "+strconv.Itoa(tahunAjaran), errors.New(fn+": tahun ajaran is not of kelas"))
+		}
+
+		var waliKelasDeletedAt *time.Time
+		err = tx.QueryRowContext(ctx, `
+			SELECT deleted_at
+			FROM public.wali_kelas
+			WHERE id=$1;`,
+			idWaliKelas).Scan(&waliKelasDeletedAt)
+
+		if err == sql.ErrNoRows {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasWaliKelasNotFound, "Wali_Kelas with id: "+strconv.Itoa(idWaliKelas)+" is not exists", errors.Wrap(err, fn+": wali kelas is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get wali kelas failed"))
+		}
+		if waliKelasDeletedAt != nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasWaliKelasDeleted, "Wali_Kelas with id: "+strconv.Itoa(idWaliKelas)+" is deleted. Choose other wali kelas", errors.New(fn+": wali kelas is deleted"))
+		}
+
+		var assigned int
+		err = tx.QueryRowContext(ctx, `
+			SELECT id_wali_kelas
+			FROM public.kelas_wali_kelas
+			WHERE id_kelas=$1 AND tahun_ajaran=$2 AND id<>$3 AND deleted_at IS NULL;`,
+			idKelas, tahunAjaran, id).Scan(&assigned)
+
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get wali kelas of kelas failed"))
+		}
+
+		return apierror.NewError(http.StatusUnprocessableEntity, apierror.KelasWaliKelasDuplicate, "Kelas with id: "+strconv.Itoa(idKelas)+" already has wali kelas with id: "+strconv.Itoa(assigned)+" in tahun ajaran "+strconv.Itoa(tahunAjaran), errors.New(fn+": kelas already has wali kelas in tahun ajaran"))
+	})
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+func TestTahunAjaran(t *testing.T) {
+	testScenarios := []struct {
+		scenarioName string
+		day          time.Time
+		expected     int
+	}{
+		{scenarioName: "last day of semester genap", day: time.Date(2026, 6, 30, 23, 0, 0, 0, time.UTC), expected: 2025},
+		{scenarioName: "first day of semester ganjil", day: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), expected: 2026},
+		{scenarioName: "new year", day: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), expected: 2026},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			if TahunAjaran(v.day) != v.expected {
+				t.Errorf("expect tahun ajaran %d, but got %d", v.expected, TahunAjaran(v.day))
+				return
+			}
+		})
+	}
+}
+
+func TestTahunAjaran_Database(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+
+	var tahunAjaran int
+	err := db.Get(&tahunAjaran, "SELECT public.tahun_ajaran(current_date)")
+	if err != nil {
+		t.Errorf("expect no error, but got %s", err)
+		return
+	}
+
+	if tahunAjaran != TahunAjaran(time.Now()) {
+		t.Errorf("expect tahun ajaran %d, but got %d", TahunAjaran(time.Now()), tahunAjaran)
+		return
+	}
+}
+
+func TestCreateKelasWaliKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKelas_Wali_KelasService(db)
+	kelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = 2026 })
+	nextKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = 2027 })
+	waliKelas := newWaliKelas(t, db)
+	otherWaliKelas := newWaliKelas(t, db, func(request *schema.CreateWali_KelasRequest) { request.Nama = "Bapak Agus Wijaya" })
+	deletedKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = 2026 })
+	deletedWaliKelas := newWaliKelas(t, db, func(request *schema.CreateWali_KelasRequest) { request.Nama = "Ibu Dewi Lestari" })
+	db.MustExec("UPDATE public.kelas SET deleted_at=now() WHERE id=$1", deletedKelas.ID)
+	db.MustExec("UPDATE public.wali_kelas SET deleted_at=now() WHERE id=$1", deletedWaliKelas.ID)
+
+	testScenarios := []struct {
+		scenarioName    string
+		prepare         func()
+		idKelas         int
+		idWaliKelas     int
+		tahunAjaran     int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "Successful assign wali kelas",
+			idKelas:      kelas.ID,
+			idWaliKelas:  waliKelas.ID,
+			tahunAjaran:  2026,
+		},
+		{
+			scenarioName:    "Error assign other wali kelas in same tahun ajaran",
+			idKelas:         kelas.ID,
+			idWaliKelas:     otherWaliKelas.ID,
+			tahunAjaran:     2026,
+			expectedErrCode: apierror.KelasWaliKelasDuplicate,
+		},
+		{
+			scenarioName: "Successful assign other wali kelas when assignment is deleted",
+			prepare: func() {
+				db.MustExec("UPDATE public.kelas_wali_kelas SET deleted_at=now() WHERE id_kelas=$1", kelas.ID)
+			},
+			idKelas:     kelas.ID,
+			idWaliKelas: otherWaliKelas.ID,
+			tahunAjaran: 2026,
+		},
+		{
+			scenarioName: "Successful assign other wali kelas to kelas of next tahun ajaran",
+			idKelas:      nextKelas.ID,
+			idWaliKelas:  otherWaliKelas.ID,
+			tahunAjaran:  2027,
+		},
+		{
+			scenarioName:    "Error assign wali kelas in tahun ajaran other than of kelas",
+			idKelas:         kelas.ID,
+			idWaliKelas:     otherWaliKelas.ID,
+			tahunAjaran:     2027,
+			expectedErrCode: apierror.KelasWaliKelasTahunAjaranMismatch,
+		},
+		{
+			scenarioName:    "Error assign wali kelas tahun ajaran is not set",
+			idKelas:         kelas.ID,
+			idWaliKelas:     waliKelas.ID,
+			expectedErrCode: apierror.KelasWaliKelasTahunAjaranRequired,
+		},
+		{
+			scenarioName:    "Error assign wali kelas kelas is not exists",
+			idKelas:         2147483647,
+			idWaliKelas:     waliKelas.ID,
+			tahunAjaran:     2026,
+			expectedErrCode: apierror.KelasWaliKelasKelasNotFound,
+		},
+		{
+			scenarioName:    "Error assign wali kelas kelas is deleted",
+			idKelas:         deletedKelas.ID,
+			idWaliKelas:     waliKelas.ID,
+			tahunAjaran:     2026,
+			expectedErrCode: apierror.KelasWaliKelasKelasDeleted,
+		},
+		{
+			scenarioName:    "Error assign wali kelas is not exists",
+			idKelas:         kelas.ID,
+			idWaliKelas:     2147483647,
+			tahunAjaran:     2026,
+			expectedErrCode: apierror.KelasWaliKelasWaliKelasNotFound,
+		},
+		{
+			scenarioName:    "Error assign wali kelas is deleted",
+			idKelas:         kelas.ID,
+			idWaliKelas:     deletedWaliKelas.ID,
+			tahunAjaran:     2026,
+			expectedErrCode: apierror.KelasWaliKelasWaliKelasDeleted,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			if v.prepare != nil {
+				v.prepare()
+			}
+
+			_, err := s.CreateKelas_Wali_Kelas(context.Background(), &schema.CreateKelas_Wali_KelasRequest{
+				IDKelas:     v.idKelas,
+				IDWaliKelas: v.idWaliKelas,
+				TahunAjaran: v.tahunAjaran,
+			})
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+		})
+	}
+}
+
+func TestUpdateKelasWaliKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKelas_Wali_KelasService(db)
+	kelas := newKelas(t, db)
+	otherKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Nama = "1B" })
+	waliKelas := newWaliKelas(t, db)
+	otherWaliKelas := newWaliKelas(t, db, func(request *schema.CreateWali_KelasRequest) { request.Nama = "Bapak Agus Wijaya" })
+	assignment := newKelasWaliKelas(t, db, kelas, waliKelas.ID)
+	otherAssignment := newKelasWaliKelas(t, db, otherKelas, otherWaliKelas.ID)
+	siswa := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas.ID })
+
+	testScenarios := []struct {
+		scenarioName        string
+		id                  int
+		request             schema.UpdateKelas_Wali_KelasRequest
+		expectedErrCode     apierror.Code
+		expectedIDWaliKelas int
+	}{
+		{
+			scenarioName:        "Successful replace wali kelas of kelas",
+			id:                  assignment.ID,
+			request:             schema.UpdateKelas_Wali_KelasRequest{IDWaliKelas: optional.Of(otherWaliKelas.ID)},
+			expectedIDWaliKelas: otherWaliKelas.ID,
+		},
+		{
+			scenarioName:        "Error move assignment to kelas that has wali kelas",
+			id:                  otherAssignment.ID,
+			request:             schema.UpdateKelas_Wali_KelasRequest{IDKelas: optional.Of(kelas.ID)},
+			expectedErrCode:     apierror.KelasWaliKelasDuplicate,
+			expectedIDWaliKelas: otherWaliKelas.ID,
+		},
+		{
+			scenarioName:        "Error move assignment to tahun ajaran other than of kelas",
+			id:                  assignment.ID,
+			request:             schema.UpdateKelas_Wali_KelasRequest{TahunAjaran: optional.Of(assignment.TahunAjaran + 1)},
+			expectedErrCode:     apierror.KelasWaliKelasTahunAjaranMismatch,
+			expectedIDWaliKelas: otherWaliKelas.ID,
+		},
+		{
+			scenarioName:        "Successful update assignment keeping its tahun ajaran",
+			id:                  assignment.ID,
+			request:             schema.UpdateKelas_Wali_KelasRequest{IDWaliKelas: optional.Of(waliKelas.ID)},
+			expectedIDWaliKelas: waliKelas.ID,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			_, err := s.UpdateKelas_Wali_Kelas(context.Background(), strconv.Itoa(v.id), &v.request)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			// siswa of kelas sees wali kelas assigned to kelas
+			got, err := NewSiswaService(db).GetSiswa(context.Background(), strconv.Itoa(siswa.ID))
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+			if got.IDWaliKelas == nil || *got.IDWaliKelas != v.expectedIDWaliKelas {
+				t.Errorf("expect id wali kelas %d, but got %v", v.expectedIDWaliKelas, got.IDWaliKelas)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+// Hasil of kenaikan kelas of siswa, values of kenaikan_type
+const (
+	HasilNaik    = "naik"
+	HasilTinggal = "tinggal"
+	HasilLulus   = "lulus"
+)
+
+// UndoPeriod is how long kenaikan kelas can be undone after it is applied
+const UndoPeriod = 7 * 24 * time.Hour
+
+// KenaikanKelasRepository ...
+type KenaikanKelasRepository interface {
+	PreviewKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (*schema.KenaikanKelasResponse, error)
+	ApplyKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (*schema.KenaikanKelasResponse, error)
+	GetKenaikanKelas(ctx context.Context, id string) (*schema.KenaikanKelasResponse, error)
+	UndoKenaikanKelas(ctx context.Context, id string) (*schema.KenaikanKelasResponse, error)
+}
+
+var _ KenaikanKelasRepository = (*KenaikanKelasService)(nil)
+
+// KenaikanKelasService moves siswa of kelas of one tahun ajaran to kelas of next one, in one
+// transaction. Every kenaikan kelas is recorded with hasil of each siswa, so it can be undone.
+type KenaikanKelasService struct {
+	db *sqlx.DB
+}
+
+// NewKenaikanKelasService ...
+func NewKenaikanKelasService(db *sqlx.DB) *KenaikanKelasService {
+	return &KenaikanKelasService{db: db}
+}
+
+// PreviewKenaikanKelas returns hasil of every siswa of request without saving it. Siswa that
+// has no kelas tujuan yet is listed without it.
+func (s *KenaikanKelasService) PreviewKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (_ *schema.KenaikanKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "KenaikanKelasService.PreviewKenaikanKelas")
+	defer func() { tracing.End(span, err) }()
+
+	kelas, siswa, err := s.load(ctx, s.db, "previewkenaikankelas", request.TahunAjaran, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hasil, err := planKenaikanKelas("previewkenaikankelas", request, kelas, siswa)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.KenaikanKelasResponse{
+		TahunAjaran:  request.TahunAjaran,
+		TingkatAkhir: request.TingkatAkhir,
+		Siswa:        hasil,
+	}, nil
+}
+
+// ApplyKenaikanKelas moves every siswa of request to its kelas tujuan and records kenaikan kelas.
+// Siswa that lulus keep their kelas. It fails when any siswa has no kelas tujuan, or when tahun
+// ajaran already has kenaikan kelas that is not undone.
+func (s *KenaikanKelasService) ApplyKenaikanKelas(ctx context.Context, request *schema.KenaikanKelasRequest) (_ *schema.KenaikanKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "KenaikanKelasService.ApplyKenaikanKelas")
+	defer func() { tracing.End(span, err) }()
+
+	var id int
+	err = dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `LOCK TABLE public.kenaikan_kelas IN SHARE ROW EXCLUSIVE MODE;`)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "applykenaikankelas: lock kenaikan kelas failed"))
+		}
+
+		var applied int
+		err = tx.QueryRowContext(ctx, `
+			SELECT id
+			FROM public.kenaikan_kelas
+			WHERE tahun_ajaran=$1 AND undone_at IS NULL AND deleted_at IS NULL;`,
+			request.TahunAjaran).Scan(&applied)
+
+		if err == nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasDuplicate, "Kenaikan kelas of tahun ajaran "+strconv.Itoa(request.TahunAjaran)+" is already applied with id: "+strconv.Itoa(applied)+". Undo it first", errors.New("applykenaikankelas: kenaikan kelas is already applied"))
+		}
+		if err != sql.ErrNoRows {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "applykenaikankelas: get kenaikan kelas failed"))
+		}
+
+		kelas, siswa, err := s.load(ctx, tx, "applykenaikankelas", request.TahunAjaran, true)
+		if err != nil {
+			return err
+		}
+
+		hasil, err := planKenaikanKelas("applykenaikankelas", request, kelas, siswa)
+		if err != nil {
+			return err
+		}
+
+		missing := []string{}
+		for _, h := range hasil {
+			if h.Hasil != HasilLulus && h.IDKelasTujuan == nil {
+				missing = append(missing, strconv.Itoa(h.IDSiswa))
+			}
+		}
+		if len(missing) > 0 {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasTujuanRequired, "Siswa with id: "+strings.Join(missing, ", ")+" have no kelas tujuan. Set kelas tujuan of their kelas or of each siswa", errors.New("applykenaikankelas: siswa have no kelas tujuan"))
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO public.kenaikan_kelas (tahun_ajaran, tingkat_akhir)
+			VALUES ($1, $2) RETURNING id;`,
+			request.TahunAjaran, request.TingkatAkhir).Scan(&id)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "applykenaikankelas: insert kenaikan kelas failed"))
+		}
+
+		for _, h := range hasil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO public.kenaikan_kelas_siswa (id_kenaikan_kelas, id_siswa, id_kelas_asal, tingkat_asal, hasil, id_kelas_tujuan, tingkat_tujuan)
+				VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+				id, h.IDSiswa, h.IDKelasAsal, h.TingkatAsal, h.Hasil, h.IDKelasTujuan, h.TingkatTujuan)
+			if err != nil {
+				return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "applykenaikankelas: insert hasil of siswa "+strconv.Itoa(h.IDSiswa)+" failed"))
+			}
+
+			if h.Hasil == HasilLulus {
+				continue
+			}
+			_, err = tx.ExecContext(ctx, `
+				UPDATE public.siswa
+				SET id_kelas=$2, tingkat=$3, updated_at=clock_timestamp()
+				WHERE id=$1;`,
+				h.IDSiswa, *h.IDKelasTujuan, *h.TingkatTujuan)
+			if err != nil {
+				return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "applykenaikankelas: move siswa "+strconv.Itoa(h.IDSiswa)+" failed"))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetKenaikanKelas(ctx, strconv.Itoa(id))
+}
+
+// GetKenaikanKelas returns kenaikan kelas with hasil of its siswa
+func (s *KenaikanKelasService) GetKenaikanKelas(ctx context.Context, id string) (_ *schema.KenaikanKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "KenaikanKelasService.GetKenaikanKelas")
+	defer func() { tracing.End(span, err) }()
+
+	if id == "" {
+		return nil, apierror.NewError(http.StatusBadRequest, apierror.KenaikanKelasIDRequired, "Kenaikan kelas id is not set", errors.New("getkenaikankelas: kenaikan kelas id is not set"))
+	}
+
+	kenaikanKelas := &schema.KenaikanKelasResponse{}
+	err = dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		err := tx.GetContext(ctx, kenaikanKelas, `
+			SELECT id, tahun_ajaran, tingkat_akhir, created_at, undone_at
+			FROM public.kenaikan_kelas
+			WHERE id=$1 AND deleted_at IS NULL;`,
+			id)
+
+		if err == sql.ErrNoRows {
+			return apierror.NewError(http.StatusNotFound, apierror.KenaikanKelasNotFound, "Kenaikan kelas with id: "+id+" is not exists", errors.Wrap(err, "getkenaikankelas: kenaikan kelas is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "getkenaikankelas: get kenaikan kelas failed"))
+		}
+
+		kenaikanKelas.Siswa = []schema.KenaikanKelasSiswaResponse{}
+		err = tx.SelectContext(ctx, &kenaikanKelas.Siswa, `
+			SELECT ks.id_siswa, s.nama, ks.id_kelas_asal, ks.tingkat_asal, ks.hasil, ks.id_kelas_tujuan, ks.tingkat_tujuan
+			FROM public.kenaikan_kelas_siswa ks
+			JOIN public.siswa s ON s.id=ks.id_siswa
+			WHERE ks.id_kenaikan_kelas=$1 AND ks.deleted_at IS NULL
+			ORDER BY ks.id;`,
+			kenaikanKelas.ID)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "getkenaikankelas: get hasil of siswa failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if kenaikanKelas.UndoneAt == nil && kenaikanKelas.CreatedAt != nil {
+		undoBefore := kenaikanKelas.CreatedAt.Add(UndoPeriod)
+		kenaikanKelas.UndoBefore = &undoBefore
+	}
+
+	return kenaikanKelas, nil
+}
+
+// UndoKenaikanKelas moves siswa of kenaikan kelas back to their kelas asal, within UndoPeriod
+// after it is applied. It fails when any siswa has changed kelas or tingkat since.
+func (s *KenaikanKelasService) UndoKenaikanKelas(ctx context.Context, id string) (_ *schema.KenaikanKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "KenaikanKelasService.UndoKenaikanKelas")
+	defer func() { tracing.End(span, err) }()
+
+	if id == "" {
+		return nil, apierror.NewError(http.StatusBadRequest, apierror.KenaikanKelasIDRequired, "Kenaikan kelas id is not set", errors.New("undokenaikankelas: kenaikan kelas id is not set"))
+	}
+
+	err = dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		kenaikanKelas := schema.KenaikanKelasResponse{}
+		err := tx.GetContext(ctx, &kenaikanKelas, `
+			SELECT id, tahun_ajaran, tingkat_akhir, created_at, undone_at
+			FROM public.kenaikan_kelas
+			WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;`,
+			id)
+
+		if err == sql.ErrNoRows {
+			return apierror.NewError(http.StatusNotFound, apierror.KenaikanKelasNotFound, "Kenaikan kelas with id: "+id+" is not exists", errors.Wrap(err, "undokenaikankelas: kenaikan kelas is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: get kenaikan kelas failed"))
+		}
+		if kenaikanKelas.UndoneAt != nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasUndone, "Kenaikan kelas with id: "+id+" is already undone", errors.New("undokenaikankelas: kenaikan kelas is already undone"))
+		}
+
+		// undo period is compared with clock of database, which wrote created_at
+		expired := false
+		err = tx.GetContext(ctx, &expired, `
+			SELECT coalesce(created_at + $2 * interval '1 second' < now(), false)
+			FROM public.kenaikan_kelas
+			WHERE id=$1;`,
+			kenaikanKelas.ID, UndoPeriod.Seconds())
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: check undo period failed"))
+		}
+		if expired {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasUndoExpired, "Kenaikan kelas with id: "+id+" can only be undone until "+kenaikanKelas.CreatedAt.Add(UndoPeriod).Format(time.RFC3339), errors.New("undokenaikankelas: undo period is over"))
+		}
+
+		moved := []struct {
+			IDSiswa       int  `db:"id_siswa"`
+			IDKelasAsal   int  `db:"id_kelas_asal"`
+			TingkatAsal   int  `db:"tingkat_asal"`
+			IDKelasTujuan int  `db:"id_kelas_tujuan"`
+			TingkatTujuan int  `db:"tingkat_tujuan"`
+			IDKelas       int  `db:"id_kelas"`
+			Tingkat       int  `db:"tingkat"`
+			Deleted       bool `db:"deleted"`
+		}{}
+		err = tx.SelectContext(ctx, &moved, `
+			SELECT ks.id_siswa, ks.id_kelas_asal, ks.tingkat_asal, ks.id_kelas_tujuan, ks.tingkat_tujuan,
+				s.id_kelas, s.tingkat, s.deleted_at IS NOT NULL AS deleted
+			FROM public.kenaikan_kelas_siswa ks
+			JOIN public.siswa s ON s.id=ks.id_siswa
+			WHERE ks.id_kenaikan_kelas=$1 AND ks.hasil<>'lulus' AND ks.deleted_at IS NULL
+			ORDER BY ks.id
+			FOR UPDATE OF s;`,
+			kenaikanKelas.ID)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: get siswa failed"))
+		}
+
+		checked := map[int]bool{}
+		for _, m := range moved {
+			if m.Deleted || m.IDKelas != m.IDKelasTujuan || m.Tingkat != m.TingkatTujuan {
+				return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasSiswaChanged, "Siswa with id: "+strconv.Itoa(m.IDSiswa)+" has changed since kenaikan kelas. Move it back by hand first", errors.New("undokenaikankelas: siswa has changed"))
+			}
+
+			if !checked[m.IDKelasAsal] {
+				err = s.validateKelasAsal(ctx, tx, m.IDKelasAsal)
+				if err != nil {
+					return err
+				}
+				checked[m.IDKelasAsal] = true
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				UPDATE public.siswa
+				SET id_kelas=$2, tingkat=$3, updated_at=clock_timestamp()
+				WHERE id=$1;`,
+				m.IDSiswa, m.IDKelasAsal, m.TingkatAsal)
+			if err != nil {
+				return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: move back siswa "+strconv.Itoa(m.IDSiswa)+" failed"))
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE public.kenaikan_kelas
+			SET undone_at=clock_timestamp(), updated_at=clock_timestamp()
+			WHERE id=$1;`,
+			kenaikanKelas.ID)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: update kenaikan kelas failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetKenaikanKelas(ctx, id)
+}
+
+// validateKelasAsal checks that kelas asal that siswa moves back to by undo exists and is not
+// deleted. Kelas is locked until undo ends, so it can't be deleted meanwhile.
+func (s *KenaikanKelasService) validateKelasAsal(ctx context.Context, tx *sqlx.Tx, idKelas int) error {
+	var deletedAt *time.Time
+	err := tx.GetContext(ctx, &deletedAt, `
+		SELECT deleted_at
+		FROM public.kelas
+		WHERE id=$1 FOR UPDATE;`,
+		idKelas)
+
+	if err == sql.ErrNoRows {
+		return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasAsalNotFound, "Kelas asal with id: "+strconv.Itoa(idKelas)+" is not exists. Move its siswa by hand", errors.Wrap(err, "undokenaikankelas: kelas asal is not exists"))
+	}
+	if err != nil {
+		return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "undokenaikankelas: get kelas asal failed"))
+	}
+	if deletedAt != nil {
+		return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasAsalDeleted, "Kelas asal with id: "+strconv.Itoa(idKelas)+" is deleted. Restore it or move its siswa by hand", errors.New("undokenaikankelas: kelas asal is deleted"))
+	}
+
+	return nil
+}
+
+// load returns kelas of tahun ajaran and of next one, and siswa of kelas of tahun ajaran. Siswa
+// are locked until transaction ends when lock is set.
+func (s *KenaikanKelasService) load(ctx context.Context, q sqlx.QueryerContext, fn string, tahunAjaran int, lock bool) ([]schema.KelasResponse, []schema.KenaikanKelasSiswaResponse, error) {
+	kelas := []schema.KelasResponse{}
+	err := sqlx.SelectContext(ctx, q, &kelas, `
+		SELECT id, nama, tingkat, tahun_ajaran
+		FROM public.kelas
+		WHERE tahun_ajaran IN ($1, $1+1) AND deleted_at IS NULL
+		ORDER BY tahun_ajaran, tingkat, nama, id;`,
+		tahunAjaran)
+	if err != nil {
+		return nil, nil, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get kelas failed"))
+	}
+
+	forUpdate := ""
+	if lock {
+		forUpdate = " FOR UPDATE OF s"
+	}
+
+	siswa := []schema.KenaikanKelasSiswaResponse{}
+	err = sqlx.SelectContext(ctx, q, &siswa, `
+		SELECT s.id AS id_siswa, s.nama, s.id_kelas AS id_kelas_asal, s.tingkat AS tingkat_asal
+		FROM public.siswa s
+		JOIN public.kelas k ON k.id=s.id_kelas
+		WHERE k.tahun_ajaran=$1 AND k.deleted_at IS NULL AND s.deleted_at IS NULL
+		ORDER BY k.tingkat, k.nama, s.nama, s.id`+forUpdate+`;`,
+		tahunAjaran)
+	if err != nil {
+		return nil, nil, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get siswa failed"))
+	}
+
+	return kelas, siswa, nil
+}
+
+// planKenaikanKelas returns hasil of every siswa of kelas of tahun ajaran of request. Siswa naik
+// to kelas tujuan of their kelas, set by request or found in next tahun ajaran, and lulus from
+// tingkat akhir, unless request overrides them. Kelas tujuan that is not found is left unset.
+func planKenaikanKelas(fn string, request *schema.KenaikanKelasRequest, kelas []schema.KelasResponse, siswa []schema.KenaikanKelasSiswaResponse) ([]schema.KenaikanKelasSiswaResponse, error) {
+	byID := map[int]schema.KelasResponse{}
+	for _, k := range kelas {
+		byID[k.ID] = k
+	}
+
+	// kelasTujuan checks that id is kelas of next tahun ajaran with tingkat
+	kelasTujuan := func(id int, tingkat int) error {
+		k, ok := byID[id]
+		if !ok || k.TahunAjaran != request.TahunAjaran+1 {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasTujuanNotFound, "Kelas with id: "+strconv.Itoa(id)+" is not kelas of tahun ajaran "+strconv.Itoa(request.TahunAjaran+1), errors.New(fn+": kelas tujuan is not exists"))
+		}
+		if k.Tingkat != tingkat {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasTujuanMismatch, "Kelas with id: "+strconv.Itoa(id)+" is of tingkat "+strconv.Itoa(k.Tingkat)+", not "+strconv.Itoa(tingkat), errors.New(fn+": kelas tujuan has other tingkat"))
+		}
+		return nil
+	}
+
+	naik := map[int]int{}
+	for _, m := range request.Kelas {
+		k, ok := byID[m.IDKelas]
+		if !ok || k.TahunAjaran != request.TahunAjaran {
+			return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasNotFound, "Kelas with id: "+strconv.Itoa(m.IDKelas)+" is not kelas of tahun ajaran "+strconv.Itoa(request.TahunAjaran), errors.New(fn+": kelas is not exists"))
+		}
+		err := kelasTujuan(m.IDKelasTujuan, k.Tingkat+1)
+		if err != nil {
+			return nil, err
+		}
+		naik[m.IDKelas] = m.IDKelasTujuan
+	}
+
+	inPlan := map[int]bool{}
+	for _, v := range siswa {
+		inPlan[v.IDSiswa] = true
+	}
+
+	overrides := map[int]schema.KenaikanKelasSiswaRequest{}
+	for _, o := range request.Siswa {
+		if !inPlan[o.IDSiswa] {
+			return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasSiswaNotFound, "Siswa with id: "+strconv.Itoa(o.IDSiswa)+" is not siswa of kelas of tahun ajaran "+strconv.Itoa(request.TahunAjaran), errors.New(fn+": siswa is not exists"))
+		}
+		if o.Hasil != HasilNaik && o.Hasil != HasilTinggal && o.Hasil != HasilLulus {
+			return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasHasilInvalid, "Hasil "+o.Hasil+" is not "+HasilNaik+", "+HasilTinggal+" or "+HasilLulus, errors.New(fn+": hasil is invalid"))
+		}
+		if o.Hasil == HasilLulus && o.IDKelasTujuan != nil {
+			return nil, apierror.NewError(http.StatusUnprocessableEntity, apierror.KenaikanKelasKelasTujuanMismatch, "Siswa with id: "+strconv.Itoa(o.IDSiswa)+" lulus, so it has no kelas tujuan", errors.New(fn+": siswa that lulus has kelas tujuan"))
+		}
+		overrides[o.IDSiswa] = o
+	}
+
+	hasil := make([]schema.KenaikanKelasSiswaResponse, 0, len(siswa))
+	for _, v := range siswa {
+		h := v
+		h.Hasil = HasilNaik
+		if v.TingkatAsal >= request.TingkatAkhir {
+			h.Hasil = HasilLulus
+		}
+
+		o, overridden := overrides[v.IDSiswa]
+		if overridden {
+			h.Hasil = o.Hasil
+		}
+
+		if h.Hasil == HasilLulus {
+			hasil = append(hasil, h)
+			continue
+		}
+
+		tingkat := v.TingkatAsal
+		if h.Hasil == HasilNaik {
+			tingkat++
+		}
+		h.TingkatTujuan = &tingkat
+
+		switch id, ok := naik[v.IDKelasAsal]; {
+		case overridden && o.IDKelasTujuan != nil:
+			err := kelasTujuan(*o.IDKelasTujuan, tingkat)
+			if err != nil {
+				return nil, err
+			}
+			h.IDKelasTujuan = o.IDKelasTujuan
+		case h.Hasil == HasilNaik && ok:
+			h.IDKelasTujuan = &id
+		default:
+			h.IDKelasTujuan = defaultKelasTujuan(byID[v.IDKelasAsal], tingkat, kelas)
+		}
+
+		hasil = append(hasil, h)
+	}
+
+	return hasil, nil
+}
+
+// defaultKelasTujuan returns kelas of tingkat in next tahun ajaran of asal with nama of asal in
+// that tingkat, e.g. 2A for 1A, or the only kelas of tingkat. It is nil when there is none.
+func defaultKelasTujuan(asal schema.KelasResponse, tingkat int, kelas []schema.KelasResponse) *int {
+	// tingkat is prefix of nama only when the next character is not a digit too, e.g. 1 of 1A but
+	// not of 10A or 12
+	nama := asal.Nama
+	if prefix := strconv.Itoa(asal.Tingkat); strings.HasPrefix(nama, prefix) {
+		rest := strings.TrimPrefix(nama, prefix)
+		if rest == "" || rest[0] < '0' || rest[0] > '9' {
+			nama = strconv.Itoa(tingkat) + rest
+		}
+	}
+
+	candidates := []int{}
+	for _, k := range kelas {
+		if k.TahunAjaran != asal.TahunAjaran+1 || k.Tingkat != tingkat {
+			continue
+		}
+		if k.Nama == nama {
+			id := k.ID
+			return &id
+		}
+		candidates = append(candidates, k.ID)
+	}
+
+	if len(candidates) != 1 {
+		return nil
+	}
+	return &candidates[0]
+}
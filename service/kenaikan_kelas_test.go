@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+)
+
+func TestPlanKenaikanKelas(t *testing.T) {
+	kelas := []schema.KelasResponse{
+		{ID: 1, Nama: "1A", Tingkat: 1, TahunAjaran: 2026},
+		{ID: 2, Nama: "1B", Tingkat: 1, TahunAjaran: 2026},
+		{ID: 3, Nama: "6A", Tingkat: 6, TahunAjaran: 2026},
+		{ID: 4, Nama: "1A", Tingkat: 1, TahunAjaran: 2027},
+		{ID: 5, Nama: "2A", Tingkat: 2, TahunAjaran: 2027},
+		{ID: 6, Nama: "2B", Tingkat: 2, TahunAjaran: 2027},
+	}
+	siswa := []schema.KenaikanKelasSiswaResponse{
+		{IDSiswa: 10, Nama: "Budi Santoso", IDKelasAsal: 1, TingkatAsal: 1},
+		{IDSiswa: 11, Nama: "Sari Dewi", IDKelasAsal: 2, TingkatAsal: 1},
+		{IDSiswa: 12, Nama: "Agus Wijaya", IDKelasAsal: 3, TingkatAsal: 6},
+	}
+	id := func(v int) *int { return &v }
+
+	testScenarios := []struct {
+		scenarioName    string
+		request         schema.KenaikanKelasRequest
+		expectedHasil   []string
+		expectedTujuan  []*int
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName:   "kelas naik to kelas of same nama, tingkat akhir lulus",
+			request:        schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6},
+			expectedHasil:  []string{HasilNaik, HasilNaik, HasilLulus},
+			expectedTujuan: []*int{id(5), id(6), nil},
+		},
+		{
+			scenarioName: "kelas tujuan of request",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Kelas: []schema.KenaikanKelasKelasRequest{
+				{IDKelas: 1, IDKelasTujuan: 6},
+			}},
+			expectedHasil:  []string{HasilNaik, HasilNaik, HasilLulus},
+			expectedTujuan: []*int{id(6), id(6), nil},
+		},
+		{
+			scenarioName: "siswa tinggal in kelas of same nama and tingkat",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 10, Hasil: HasilTinggal},
+			}},
+			expectedHasil:  []string{HasilTinggal, HasilNaik, HasilLulus},
+			expectedTujuan: []*int{id(4), id(6), nil},
+		},
+		{
+			scenarioName: "siswa naik to kelas tujuan of override",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 11, Hasil: HasilNaik, IDKelasTujuan: id(5)},
+			}},
+			expectedHasil:  []string{HasilNaik, HasilNaik, HasilLulus},
+			expectedTujuan: []*int{id(5), id(5), nil},
+		},
+		{
+			scenarioName: "siswa of tingkat akhir tinggal without kelas tujuan",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 12, Hasil: HasilTinggal},
+			}},
+			expectedHasil:  []string{HasilNaik, HasilNaik, HasilTinggal},
+			expectedTujuan: []*int{id(5), id(6), nil},
+		},
+		{
+			scenarioName: "Error kelas is not of tahun ajaran",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Kelas: []schema.KenaikanKelasKelasRequest{
+				{IDKelas: 4, IDKelasTujuan: 5},
+			}},
+			expectedErrCode: apierror.KenaikanKelasKelasNotFound,
+		},
+		{
+			scenarioName: "Error kelas tujuan is not of next tahun ajaran",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Kelas: []schema.KenaikanKelasKelasRequest{
+				{IDKelas: 1, IDKelasTujuan: 2},
+			}},
+			expectedErrCode: apierror.KenaikanKelasKelasTujuanNotFound,
+		},
+		{
+			scenarioName: "Error kelas tujuan is of other tingkat",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 10, Hasil: HasilNaik, IDKelasTujuan: id(4)},
+			}},
+			expectedErrCode: apierror.KenaikanKelasKelasTujuanMismatch,
+		},
+		{
+			scenarioName: "Error siswa that lulus has kelas tujuan",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 10, Hasil: HasilLulus, IDKelasTujuan: id(5)},
+			}},
+			expectedErrCode: apierror.KenaikanKelasKelasTujuanMismatch,
+		},
+		{
+			scenarioName: "Error siswa is not of tahun ajaran",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 99, Hasil: HasilNaik},
+			}},
+			expectedErrCode: apierror.KenaikanKelasSiswaNotFound,
+		},
+		{
+			scenarioName: "Error hasil is invalid",
+			request: schema.KenaikanKelasRequest{TahunAjaran: 2026, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: 10, Hasil: "pindah"},
+			}},
+			expectedErrCode: apierror.KenaikanKelasHasilInvalid,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			hasil, err := planKenaikanKelas("test", &v.request, kelas, siswa)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			gotHasil := []string{}
+			gotTujuan := []*int{}
+			for _, h := range hasil {
+				gotHasil = append(gotHasil, h.Hasil)
+				gotTujuan = append(gotTujuan, h.IDKelasTujuan)
+			}
+			if !reflect.DeepEqual(gotHasil, v.expectedHasil) {
+				t.Errorf("expect hasil %v, but got %v", v.expectedHasil, gotHasil)
+				return
+			}
+			if !reflect.DeepEqual(gotTujuan, v.expectedTujuan) {
+				t.Errorf("expect kelas tujuan %v, but got %v", v.expectedTujuan, gotTujuan)
+				return
+			}
+		})
+	}
+}
+
+func TestDefaultKelasTujuan(t *testing.T) {
+	kelas := []schema.KelasResponse{
+		{ID: 1, Nama: "2A", Tingkat: 2, TahunAjaran: 2027},
+		{ID: 2, Nama: "20A", Tingkat: 2, TahunAjaran: 2027},
+		{ID: 3, Nama: "11A", Tingkat: 11, TahunAjaran: 2027},
+		{ID: 4, Nama: "11B", Tingkat: 11, TahunAjaran: 2027},
+		{ID: 5, Nama: "Kelas 2", Tingkat: 2, TahunAjaran: 2027},
+	}
+	id := func(v int) *int { return &v }
+
+	testScenarios := []struct {
+		scenarioName   string
+		asal           schema.KelasResponse
+		tingkat        int
+		expectedTujuan *int
+	}{
+		{
+			scenarioName:   "tingkat prefix of nama",
+			asal:           schema.KelasResponse{Nama: "1A", Tingkat: 1, TahunAjaran: 2026},
+			tingkat:        2,
+			expectedTujuan: id(1),
+		},
+		{
+			scenarioName:   "tingkat of two digits",
+			asal:           schema.KelasResponse{Nama: "10A", Tingkat: 10, TahunAjaran: 2026},
+			tingkat:        11,
+			expectedTujuan: id(3),
+		},
+		{
+			scenarioName:   "nama starting with digit of tingkat but longer number",
+			asal:           schema.KelasResponse{Nama: "10A", Tingkat: 1, TahunAjaran: 2026},
+			tingkat:        2,
+			expectedTujuan: nil,
+		},
+		{
+			scenarioName:   "nama without tingkat prefix",
+			asal:           schema.KelasResponse{Nama: "Kelas 2", Tingkat: 1, TahunAjaran: 2026},
+			tingkat:        2,
+			expectedTujuan: id(5),
+		},
+	}
+
+	for _, v := range testScenarios {
+		got := defaultKelasTujuan(v.asal, v.tingkat, kelas)
+		if !reflect.DeepEqual(got, v.expectedTujuan) {
+			t.Errorf("%s: expect kelas tujuan %v, but got %v", v.scenarioName, v.expectedTujuan, got)
+			return
+		}
+	}
+}
+
+func TestApplyKenaikanKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKenaikanKelasService(db)
+	tahunAjaran := newTahunAjaran()
+	kelas1A := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = tahunAjaran })
+	kelas6A := newKelas(t, db, func(request *schema.CreateKelasRequest) {
+		request.Nama, request.Tingkat, request.TahunAjaran = "6A", 6, tahunAjaran
+	})
+	kelas2A := newKelas(t, db, func(request *schema.CreateKelasRequest) {
+		request.Nama, request.Tingkat, request.TahunAjaran = "2A", 2, tahunAjaran+1
+	})
+	budi := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas1A.ID })
+	sari := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.Nama, request.IDKelas = "Sari Dewi", kelas1A.ID })
+	agus := newSiswa(t, db, func(request *schema.CreateSiswaRequest) {
+		request.Nama, request.IDKelas, request.Tingkat = "Agus Wijaya", kelas6A.ID, 6
+	})
+
+	testScenarios := []struct {
+		scenarioName    string
+		request         schema.KenaikanKelasRequest
+		expectedErrCode apierror.Code
+		expectedKelas   []int
+		expectedLulus   []bool
+	}{
+		{
+			scenarioName: "Error siswa tinggal without kelas tujuan",
+			request: schema.KenaikanKelasRequest{TahunAjaran: tahunAjaran, TingkatAkhir: 6, Siswa: []schema.KenaikanKelasSiswaRequest{
+				{IDSiswa: sari.ID, Hasil: HasilTinggal},
+			}},
+			expectedErrCode: apierror.KenaikanKelasKelasTujuanRequired,
+			expectedKelas:   []int{kelas1A.ID, kelas1A.ID, kelas6A.ID},
+			expectedLulus:   []bool{false, false, false},
+		},
+		{
+			scenarioName:  "Successful apply kenaikan kelas",
+			request:       schema.KenaikanKelasRequest{TahunAjaran: tahunAjaran, TingkatAkhir: 6},
+			expectedKelas: []int{kelas2A.ID, kelas2A.ID, kelas6A.ID},
+			expectedLulus: []bool{false, false, true},
+		},
+		{
+			scenarioName:    "Error apply kenaikan kelas of tahun ajaran twice",
+			request:         schema.KenaikanKelasRequest{TahunAjaran: tahunAjaran, TingkatAkhir: 6},
+			expectedErrCode: apierror.KenaikanKelasDuplicate,
+			expectedKelas:   []int{kelas2A.ID, kelas2A.ID, kelas6A.ID},
+			expectedLulus:   []bool{false, false, true},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			_, err := s.ApplyKenaikanKelas(context.Background(), &v.request)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			for i, siswa := range []*schema.SiswaResponse{budi, sari, agus} {
+				got, err := NewSiswaService(db).GetSiswa(context.Background(), strconv.Itoa(siswa.ID))
+				if err != nil {
+					t.Errorf("expect no error, but got %s", err)
+					return
+				}
+				if got.IDKelas != v.expectedKelas[i] {
+					t.Errorf("expect siswa %s in kelas %d, but got %d", got.Nama, v.expectedKelas[i], got.IDKelas)
+					return
+				}
+				if got.Lulus == nil || *got.Lulus != v.expectedLulus[i] {
+					t.Errorf("expect siswa %s lulus %t, but got %v", got.Nama, v.expectedLulus[i], got.Lulus)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestUndoKenaikanKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewKenaikanKelasService(db)
+	tahunAjaran := newTahunAjaran()
+	kelas1A := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.TahunAjaran = tahunAjaran })
+	kelas1B := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Nama, request.TahunAjaran = "1B", tahunAjaran+1 })
+	kelas2A := newKelas(t, db, func(request *schema.CreateKelasRequest) {
+		request.Nama, request.Tingkat, request.TahunAjaran = "2A", 2, tahunAjaran+1
+	})
+	kelas2B := newKelas(t, db, func(request *schema.CreateKelasRequest) {
+		request.Nama, request.Tingkat, request.TahunAjaran = "2B", 2, tahunAjaran+1
+	})
+	budi := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas1A.ID })
+	sari := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.Nama, request.IDKelas = "Sari Dewi", kelas1A.ID })
+
+	var applied *schema.KenaikanKelasResponse
+	apply := func(t *testing.T, tahunAjaran int) *schema.KenaikanKelasResponse {
+		kenaikanKelas, err := s.ApplyKenaikanKelas(context.Background(), &schema.KenaikanKelasRequest{
+			TahunAjaran:  tahunAjaran,
+			TingkatAkhir: 6,
+			Kelas:        []schema.KenaikanKelasKelasRequest{{IDKelas: kelas1A.ID, IDKelasTujuan: kelas2A.ID}},
+			Siswa:        []schema.KenaikanKelasSiswaRequest{{IDSiswa: sari.ID, Hasil: HasilTinggal, IDKelasTujuan: &kelas1B.ID}},
+		})
+		if err != nil {
+			t.Fatalf("apply kenaikan kelas failed: %s", err)
+		}
+		return kenaikanKelas
+	}
+
+	testScenarios := []struct {
+		scenarioName    string
+		prepare         func(t *testing.T) string
+		expectedErrCode apierror.Code
+		expectedKelas   []int
+	}{
+		{
+			scenarioName:    "Error undo kenaikan kelas that is not exists",
+			prepare:         func(t *testing.T) string { return missingID },
+			expectedErrCode: apierror.KenaikanKelasNotFound,
+			expectedKelas:   []int{kelas1A.ID, kelas1A.ID},
+		},
+		{
+			scenarioName:  "Successful undo",
+			prepare:       func(t *testing.T) string { return strconv.Itoa(apply(t, tahunAjaran).ID) },
+			expectedKelas: []int{kelas1A.ID, kelas1A.ID},
+		},
+		{
+			scenarioName: "Error undo kenaikan kelas that is undone",
+			prepare: func(t *testing.T) string {
+				kenaikanKelas := apply(t, tahunAjaran)
+				_, err := s.UndoKenaikanKelas(context.Background(), strconv.Itoa(kenaikanKelas.ID))
+				if err != nil {
+					t.Fatalf("undo kenaikan kelas failed: %s", err)
+				}
+				return strconv.Itoa(kenaikanKelas.ID)
+			},
+			expectedErrCode: apierror.KenaikanKelasUndone,
+			expectedKelas:   []int{kelas1A.ID, kelas1A.ID},
+		},
+		{
+			scenarioName: "Error undo when siswa has changed kelas",
+			prepare: func(t *testing.T) string {
+				applied = apply(t, tahunAjaran)
+				db.MustExec("UPDATE public.siswa SET id_kelas=$1 WHERE id=$2", kelas2B.ID, budi.ID)
+				return strconv.Itoa(applied.ID)
+			},
+			expectedErrCode: apierror.KenaikanKelasSiswaChanged,
+			expectedKelas:   []int{kelas2B.ID, kelas1B.ID},
+		},
+		{
+			scenarioName: "Error undo after undo period",
+			prepare: func(t *testing.T) string {
+				db.MustExec("UPDATE public.siswa SET id_kelas=$1 WHERE id=$2", kelas2A.ID, budi.ID)
+				db.MustExec("UPDATE public.kenaikan_kelas SET created_at=now() - interval '8 days' WHERE id=$1", applied.ID)
+				return strconv.Itoa(applied.ID)
+			},
+			expectedErrCode: apierror.KenaikanKelasUndoExpired,
+			expectedKelas:   []int{kelas2A.ID, kelas1B.ID},
+		},
+		{
+			scenarioName: "Error undo when kelas asal is deleted",
+			prepare: func(t *testing.T) string {
+				db.MustExec("UPDATE public.kenaikan_kelas SET created_at=now() WHERE id=$1", applied.ID)
+				db.MustExec("UPDATE public.kelas SET deleted_at=now() WHERE id=$1", kelas1A.ID)
+				return strconv.Itoa(applied.ID)
+			},
+			expectedErrCode: apierror.KenaikanKelasKelasAsalDeleted,
+			expectedKelas:   []int{kelas2A.ID, kelas1B.ID},
+		},
+		{
+			scenarioName: "Successful undo at the end of undo period",
+			prepare: func(t *testing.T) string {
+				db.MustExec("UPDATE public.kelas SET deleted_at=NULL WHERE id=$1", kelas1A.ID)
+				db.MustExec("UPDATE public.kenaikan_kelas SET created_at=now() - $2 * interval '1 second' WHERE id=$1", applied.ID, UndoPeriod.Seconds())
+				return strconv.Itoa(applied.ID)
+			},
+			expectedKelas: []int{kelas1A.ID, kelas1A.ID},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			id := v.prepare(t)
+
+			_, err := s.UndoKenaikanKelas(context.Background(), id)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			for i, siswa := range []*schema.SiswaResponse{budi, sari} {
+				got, err := NewSiswaService(db).GetSiswa(context.Background(), strconv.Itoa(siswa.ID))
+				if err != nil {
+					t.Errorf("expect no error, but got %s", err)
+					return
+				}
+				if got.IDKelas != v.expectedKelas[i] {
+					t.Errorf("expect siswa %s in kelas %d, but got %d", got.Nama, v.expectedKelas[i], got.IDKelas)
+					return
+				}
+			}
+		})
+	}
+}
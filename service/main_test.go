@@ -0,0 +1,62 @@
+package service
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtest"
+)
+
+// missingID is id that no row has
+const missingID = "2147483647"
+
+var testDSN string
+
+func TestMain(m *testing.M) {
+	testDSN = os.Getenv("TEST_DB_CONNECTION_STR")
+	if len(testDSN) == 0 {
+		log.Fatalln("Database connection string is not set. Set TEST_DB_CONNECTION_STR in environment")
+	}
+
+	// fail once here instead of in every test
+	db, err := dbtest.Open(testDSN)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	db.Close()
+
+	code := m.Run()
+	os.Exit(code)
+}
+
+// newTestDB returns database of one test. Everything test writes is rolled back when it ends,
+// so tests only see their own rows, and run alone, in any order or in parallel.
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := dbtest.Open(testDSN)
+	if err != nil {
+		t.Fatalf("open test database failed: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// errorCode returns application error code of err, or empty code if err is nil
+func errorCode(err error) apierror.Code {
+	if err == nil {
+		return ""
+	}
+
+	ae, ok := err.(*apierror.APIError)
+	if !ok {
+		return apierror.Code(err.Error())
+	}
+
+	return ae.Code
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"github.com/syukur91/ischool-monitor/api/schema"
+)
+
+// deriveSiswa sets tahun ajaran of kelas of siswa, and wali kelas assigned to kelas for that
+// tahun ajaran, like siswaDerived of service selects them. Store has no kenaikan kelas, so siswa
+// never lulus. Caller holds lock.
+func (s *Store) deriveSiswa(siswa *schema.SiswaResponse) {
+	lulus := false
+	siswa.Lulus = &lulus
+	siswa.IDWaliKelas = nil
+	siswa.TahunAjaran = nil
+
+	kelas := s.table("kelas")
+	i := kelas.find(siswa.IDKelas)
+	if i < 0 {
+		return
+	}
+	tahunAjaran := kelas.rows[i].value.(schema.KelasResponse).TahunAjaran
+	siswa.TahunAjaran = &tahunAjaran
+
+	for _, r := range s.table("kelas_wali_kelas").rows {
+		assignment := r.value.(schema.Kelas_Wali_KelasResponse)
+		if assignment.IDKelas == siswa.IDKelas && assignment.TahunAjaran == tahunAjaran {
+			idWaliKelas := assignment.IDWaliKelas
+			siswa.IDWaliKelas = &idWaliKelas
+			return
+		}
+	}
+}
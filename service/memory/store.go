@@ -0,0 +1,104 @@
+// Package memory implements service repositories with rows kept in memory, so handlers can be
+// tested without database. Generated stores check required, unique and foreign key fields with
+// the same errors as services, and list rows with query.Apply, which filters, sorts and pages
+// like PostgreSQL does. Hooks of hand-written service files, such as validateCreate, are not run.
+// Derived fields are set by hand-written derive{Model} of Store, such as deriveSiswa in siswa.go.
+package memory
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/service"
+)
+
+// Store is an in-memory database shared by stores of every model, so foreign keys can be checked
+type Store struct {
+	mu     sync.Mutex
+	tables map[string]*table
+
+	// lastTime is last time that now returned
+	lastTime time.Time
+}
+
+var _ service.Store = (*Store)(nil)
+
+// New returns empty store
+func New() *Store {
+	return &Store{tables: map[string]*table{}}
+}
+
+// table keeps rows of one table in insert order, like a heap without updates does
+type table struct {
+	lastID int
+	rows   []row
+}
+
+// row is a schema response value with its id
+type row struct {
+	id    int
+	value interface{}
+}
+
+// table returns table by name, creating it when missing. Caller holds lock.
+func (s *Store) table(name string) *table {
+	t, ok := s.tables[name]
+	if !ok {
+		t = &table{}
+		s.tables[name] = t
+	}
+
+	return t
+}
+
+// now returns current time, truncated to microseconds like PostgreSQL keeps it, and after the time
+// it returned before, so updated_at and ETag change on every write. Caller holds lock.
+func (s *Store) now() time.Time {
+	t := time.Now().Truncate(time.Microsecond)
+	if !t.After(s.lastTime) {
+		t = s.lastTime.Add(time.Microsecond)
+	}
+	s.lastTime = t
+
+	return t
+}
+
+// exists returns true when table has row with id. Caller holds lock.
+func (s *Store) exists(name string, id int) bool {
+	return s.table(name).find(id) > -1
+}
+
+func (t *table) nextID() int {
+	t.lastID++
+	return t.lastID
+}
+
+// find returns index of row with id, or -1
+func (t *table) find(id int) int {
+	for i, r := range t.rows {
+		if r.id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (t *table) remove(i int) {
+	t.rows = append(t.rows[:i:i], t.rows[i+1:]...)
+}
+
+// parseID converts id parameter like PostgreSQL casts it to int
+func parseID(id string, fn string) (int, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": invalid input syntax for type integer"))
+	}
+
+	return n, nil
+}
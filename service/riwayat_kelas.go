@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+// RiwayatKelasRepository ...
+type RiwayatKelasRepository interface {
+	ListRiwayatKelas(ctx context.Context, idSiswa string) ([]schema.RiwayatKelasResponse, error)
+	ListAnggotaKelas(ctx context.Context, idKelas string, tanggal *time.Time) ([]schema.AnggotaKelasResponse, error)
+}
+
+var _ RiwayatKelasRepository = (*RiwayatKelasService)(nil)
+
+// RiwayatKelasService reads riwayat kelas of siswa. Riwayat is written by trigger of siswa in
+// database whenever its kelas changes, see migration 0005, so there is nothing to write here.
+type RiwayatKelasService struct {
+	db *sqlx.DB
+}
+
+// NewRiwayatKelasService ...
+func NewRiwayatKelasService(db *sqlx.DB) *RiwayatKelasService {
+	return &RiwayatKelasService{db: db}
+}
+
+// ListRiwayatKelas returns every kelas of siswa, oldest first
+func (s *RiwayatKelasService) ListRiwayatKelas(ctx context.Context, idSiswa string) (_ []schema.RiwayatKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "RiwayatKelasService.ListRiwayatKelas")
+	defer func() { tracing.End(span, err) }()
+
+	if idSiswa == "" {
+		return nil, apierror.NewError(http.StatusBadRequest, apierror.RiwayatKelasSiswaRequired, "Siswa id is not set", errors.New("listriwayatkelas: siswa id is not set"))
+	}
+
+	riwayat := []schema.RiwayatKelasResponse{}
+	err = dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		err := exists(ctx, tx, "siswa", idSiswa)
+		if err == errNotExists {
+			return apierror.NewError(http.StatusNotFound, apierror.RiwayatKelasSiswaNotFound, "Siswa with id: "+idSiswa+" is not exists", errors.Wrap(err, "listriwayatkelas: siswa is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "listriwayatkelas: get siswa failed"))
+		}
+
+		err = tx.SelectContext(ctx, &riwayat, `
+			SELECT r.id, r.id_siswa, r.id_kelas, k.nama AS nama_kelas, k.tingkat, k.tahun_ajaran, r.mulai, r.akhir
+			FROM public.riwayat_kelas r
+			JOIN public.kelas k ON k.id=r.id_kelas
+			WHERE r.id_siswa=$1 AND r.deleted_at IS NULL
+			ORDER BY r.mulai, r.id;`,
+			idSiswa)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "listriwayatkelas: get riwayat kelas failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return riwayat, nil
+}
+
+// ListAnggotaKelas returns siswa that were in kelas on tanggal, by nama. Deleted siswa are left out.
+// Nil tanggal is current date of database, the same clock that trigger of siswa uses for mulai.
+func (s *RiwayatKelasService) ListAnggotaKelas(ctx context.Context, idKelas string, tanggal *time.Time) (_ []schema.AnggotaKelasResponse, err error) {
+	ctx, span := tracing.Start(ctx, "RiwayatKelasService.ListAnggotaKelas")
+	defer func() { tracing.End(span, err) }()
+
+	if idKelas == "" {
+		return nil, apierror.NewError(http.StatusBadRequest, apierror.RiwayatKelasKelasRequired, "Kelas id is not set", errors.New("listanggotakelas: kelas id is not set"))
+	}
+
+	var date *string
+	if tanggal != nil {
+		v := tanggal.Format("2006-01-02")
+		date = &v
+	}
+
+	anggota := []schema.AnggotaKelasResponse{}
+	err = dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		err := exists(ctx, tx, "kelas", idKelas)
+		if err == errNotExists {
+			return apierror.NewError(http.StatusNotFound, apierror.RiwayatKelasKelasNotFound, "Kelas with id: "+idKelas+" is not exists", errors.Wrap(err, "listanggotakelas: kelas is not exists"))
+		}
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "listanggotakelas: get kelas failed"))
+		}
+
+		err = tx.SelectContext(ctx, &anggota, `
+			SELECT r.id_siswa, s.nama, r.mulai, r.akhir
+			FROM public.riwayat_kelas r
+			JOIN public.siswa s ON s.id=r.id_siswa
+			WHERE r.id_kelas=$1 AND r.mulai<=coalesce($2::date, current_date)
+				AND (r.akhir IS NULL OR r.akhir>coalesce($2::date, current_date))
+				AND r.deleted_at IS NULL AND s.deleted_at IS NULL
+			ORDER BY s.nama, r.id_siswa;`,
+			idKelas, date)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "listanggotakelas: get siswa of kelas failed"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return anggota, nil
+}
+
+// errNotExists is error of exists for row that is not exists
+var errNotExists = errors.New("row is not exists")
+
+// exists returns errNotExists when table has no row with id, deleted or not
+func exists(ctx context.Context, tx *sqlx.Tx, table string, id string) error {
+	found := false
+	err := tx.GetContext(ctx, &found, `SELECT EXISTS (SELECT 1 FROM public.`+table+` WHERE id=$1);`, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errNotExists
+	}
+
+	return nil
+}
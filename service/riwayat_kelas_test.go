@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+func TestListRiwayatKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewRiwayatKelasService(db)
+	kelas1A := newKelas(t, db)
+	kelas1B := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Nama = "1B" })
+	budi := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas1A.ID })
+
+	update := func(t *testing.T, request *schema.UpdateSiswaRequest) {
+		_, err := NewSiswaService(db).UpdateSiswa(context.Background(), strconv.Itoa(budi.ID), request)
+		if err != nil {
+			t.Fatalf("update siswa failed: %s", err)
+		}
+	}
+
+	testScenarios := []struct {
+		scenarioName    string
+		prepare         func(t *testing.T) string
+		expectedErrCode apierror.Code
+		expectedKelas   []int
+		expectedOpen    []bool
+	}{
+		{
+			scenarioName:    "Error siswa id is not set",
+			prepare:         func(t *testing.T) string { return "" },
+			expectedErrCode: apierror.RiwayatKelasSiswaRequired,
+		},
+		{
+			scenarioName:    "Error siswa is not exists",
+			prepare:         func(t *testing.T) string { return missingID },
+			expectedErrCode: apierror.RiwayatKelasSiswaNotFound,
+		},
+		{
+			scenarioName:  "Created siswa is in its kelas",
+			prepare:       func(t *testing.T) string { return strconv.Itoa(budi.ID) },
+			expectedKelas: []int{kelas1A.ID},
+			expectedOpen:  []bool{true},
+		},
+		{
+			scenarioName: "Update of kelas ends riwayat of old kelas",
+			prepare: func(t *testing.T) string {
+				update(t, &schema.UpdateSiswaRequest{IDKelas: optional.Of(kelas1B.ID)})
+				return strconv.Itoa(budi.ID)
+			},
+			expectedKelas: []int{kelas1A.ID, kelas1B.ID},
+			expectedOpen:  []bool{false, true},
+		},
+		{
+			scenarioName: "Update without change of kelas keeps riwayat",
+			prepare: func(t *testing.T) string {
+				update(t, &schema.UpdateSiswaRequest{Nama: optional.Of("Budi Setiawan"), IDKelas: optional.Of(kelas1B.ID)})
+				return strconv.Itoa(budi.ID)
+			},
+			expectedKelas: []int{kelas1A.ID, kelas1B.ID},
+			expectedOpen:  []bool{false, true},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			id := v.prepare(t)
+
+			riwayat, err := s.ListRiwayatKelas(context.Background(), id)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			if len(riwayat) != len(v.expectedKelas) {
+				t.Errorf("expect %d riwayat kelas, but got %d", len(v.expectedKelas), len(riwayat))
+				return
+			}
+			for i, r := range riwayat {
+				if r.IDKelas != v.expectedKelas[i] {
+					t.Errorf("expect riwayat %d in kelas %d, but got %d", i, v.expectedKelas[i], r.IDKelas)
+					return
+				}
+				if (r.Akhir == nil) != v.expectedOpen[i] {
+					t.Errorf("expect riwayat %d open %t, but got akhir %v", i, v.expectedOpen[i], r.Akhir)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestListAnggotaKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewRiwayatKelasService(db)
+	kelas1A := newKelas(t, db)
+	kelas1B := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Nama = "1B" })
+	budi := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas1A.ID })
+	sari := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.Nama, request.IDKelas = "Sari Dewi", kelas1A.ID })
+
+	_, err := NewSiswaService(db).UpdateSiswa(context.Background(), strconv.Itoa(budi.ID), &schema.UpdateSiswaRequest{IDKelas: optional.Of(kelas1B.ID)})
+	if err != nil {
+		t.Fatalf("update siswa failed: %s", err)
+	}
+
+	// budi is in 1A from 2090-01-01 until 2090-03-01 then in 1B, sari stays in 1A
+	db.MustExec("UPDATE public.riwayat_kelas SET mulai='2090-01-01' WHERE id_kelas=$1", kelas1A.ID)
+	db.MustExec("UPDATE public.riwayat_kelas SET akhir='2090-03-01' WHERE id_kelas=$1 AND akhir IS NOT NULL", kelas1A.ID)
+	db.MustExec("UPDATE public.riwayat_kelas SET mulai='2090-03-01' WHERE id_kelas=$1", kelas1B.ID)
+
+	date := func(v string) *time.Time {
+		tanggal, _ := time.Parse("2006-01-02", v)
+		return &tanggal
+	}
+
+	testScenarios := []struct {
+		scenarioName    string
+		idKelas         string
+		tanggal         *time.Time
+		expectedErrCode apierror.Code
+		expectedSiswa   []int
+	}{
+		{
+			scenarioName:    "Error kelas id is not set",
+			tanggal:         date("2090-02-01"),
+			expectedErrCode: apierror.RiwayatKelasKelasRequired,
+		},
+		{
+			scenarioName:    "Error kelas is not exists",
+			idKelas:         missingID,
+			tanggal:         date("2090-02-01"),
+			expectedErrCode: apierror.RiwayatKelasKelasNotFound,
+		},
+		{
+			scenarioName:  "Kelas before mulai of every siswa",
+			idKelas:       strconv.Itoa(kelas1A.ID),
+			tanggal:       date("2089-12-31"),
+			expectedSiswa: []int{},
+		},
+		{
+			scenarioName:  "Kelas on mulai",
+			idKelas:       strconv.Itoa(kelas1A.ID),
+			tanggal:       date("2090-01-01"),
+			expectedSiswa: []int{budi.ID, sari.ID},
+		},
+		{
+			scenarioName:  "Kelas on akhir of siswa that moved",
+			idKelas:       strconv.Itoa(kelas1A.ID),
+			tanggal:       date("2090-03-01"),
+			expectedSiswa: []int{sari.ID},
+		},
+		{
+			scenarioName:  "Kelas before siswa moved in",
+			idKelas:       strconv.Itoa(kelas1B.ID),
+			tanggal:       date("2090-02-28"),
+			expectedSiswa: []int{},
+		},
+		{
+			scenarioName:  "Kelas after siswa moved in",
+			idKelas:       strconv.Itoa(kelas1B.ID),
+			tanggal:       date("2090-03-01"),
+			expectedSiswa: []int{budi.ID},
+		},
+		{
+			scenarioName:  "Kelas without tanggal is on current date of database",
+			idKelas:       strconv.Itoa(kelas1B.ID),
+			expectedSiswa: []int{},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			anggota, err := s.ListAnggotaKelas(context.Background(), v.idKelas, v.tanggal)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			if len(anggota) != len(v.expectedSiswa) {
+				t.Errorf("expect %d siswa, but got %d", len(v.expectedSiswa), len(anggota))
+				return
+			}
+			for i, a := range anggota {
+				if a.IDSiswa != v.expectedSiswa[i] {
+					t.Errorf("expect siswa %d at %d, but got %d", v.expectedSiswa[i], i, a.IDSiswa)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteWithRiwayatKelas(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	kelas1A := newKelas(t, db)
+	budi := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.IDKelas = kelas1A.ID })
+
+	err := NewKelasService(db).DeleteKelas(context.Background(), strconv.Itoa(kelas1A.ID))
+	if errorCode(err) != apierror.StillReferenced {
+		t.Errorf("expect delete of kelas with riwayat to fail with %s, but got %v", apierror.StillReferenced, err)
+		return
+	}
+
+	err = NewSiswaService(db).DeleteSiswa(context.Background(), strconv.Itoa(budi.ID))
+	if err != nil {
+		t.Errorf("expect delete of siswa with riwayat to succeed, but got %s", err)
+		return
+	}
+
+	riwayat := 0
+	db.Get(&riwayat, "SELECT count(*) FROM public.riwayat_kelas WHERE id_siswa=$1", budi.ID)
+	if riwayat != 0 {
+		t.Errorf("expect riwayat of deleted siswa to be deleted, but got %d", riwayat)
+		return
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+)
+
+// Hand-written part of SemesterService. Generated CRUD lives in semester_gen.go,
+// which is overwritten on every generate. This file is only created when missing.
+
+// Semesters of tahun ajaran, values of semester_type
+const (
+	SemesterGanjil = "ganjil"
+	SemesterGenap  = "genap"
+)
+
+// validateCreate is called before semester is inserted, after required fields are checked
+func (s *SemesterService) validateCreate(ctx context.Context, request *schema.CreateSemesterRequest) error {
+	return s.validateSemester(ctx, "createsemester", 0, request.TahunAjaran, request.Semester, request.Mulai, request.Akhir, request.Aktif)
+}
+
+// validateUpdate is called with existing semester merged with update request or replaced by replace
+// request, before it is saved
+func (s *SemesterService) validateUpdate(ctx context.Context, semester *schema.SemesterResponse) error {
+	return s.validateSemester(ctx, "updatesemester", semester.ID, semester.TahunAjaran, semester.Semester, semester.Mulai, semester.Akhir, semester.Aktif)
+}
+
+// validateSemester checks that semester is ganjil or genap, ends on or after it starts and is not
+// in tahun ajaran twice. Semester that is saved active deactivates the one that was, so at most one
+// semester is active in database, which is one tenant, see migration 0003. Table is locked until semester is saved, so concurrent writes can't activate
+// two. Id is 0 for semester that is created.
+func (s *SemesterService) validateSemester(ctx context.Context, fn string, id int, tahunAjaran int, semester string, mulai time.Time, akhir time.Time, aktif bool) error {
+	if semester != SemesterGanjil && semester != SemesterGenap {
+		return apierror.NewError(http.StatusUnprocessableEntity, apierror.SemesterSemesterInvalid, "Semester "+semester+" is not "+SemesterGanjil+" or "+SemesterGenap, errors.New(fn+": semester is invalid"))
+	}
+	if akhir.Before(mulai) {
+		return apierror.NewError(http.StatusUnprocessableEntity, apierror.SemesterAkhirBeforeMulai, "Semester akhir "+akhir.Format("2006-01-02")+" is before mulai "+mulai.Format("2006-01-02"), errors.New(fn+": semester akhir is before mulai"))
+	}
+
+	return dbtx.WithTx(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `LOCK TABLE public.semester IN SHARE ROW EXCLUSIVE MODE;`)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": lock semester failed"))
+		}
+
+		var duplicate int
+		err = tx.QueryRowContext(ctx, `
+			SELECT id
+			FROM public.semester
+			WHERE tahun_ajaran=$1 AND semester=$2 AND id<>$3 AND deleted_at IS NULL;`,
+			tahunAjaran, semester, id).Scan(&duplicate)
+
+		if err == nil {
+			return apierror.NewError(http.StatusUnprocessableEntity, apierror.SemesterDuplicate, "Semester "+semester+" of tahun ajaran "+strconv.Itoa(tahunAjaran)+" already exists with id: "+strconv.Itoa(duplicate), errors.New(fn+": semester already exists"))
+		}
+		if err != sql.ErrNoRows {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": get semester failed"))
+		}
+
+		if !aktif {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE public.semester
+			SET aktif=false, updated_at=clock_timestamp()
+			WHERE aktif AND id<>$1;`,
+			id)
+		if err != nil {
+			return apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, fn+": deactivate semester failed"))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/optional"
+)
+
+func TestCreateSemester(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewSemesterService(db)
+	tahunAjaran := newTahunAjaran()
+
+	testScenarios := []struct {
+		scenarioName    string
+		request         schema.CreateSemesterRequest
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "Successful add semester ganjil",
+			request: schema.CreateSemesterRequest{
+				TahunAjaran: tahunAjaran,
+				Semester:    SemesterGanjil,
+				Mulai:       time.Date(tahunAjaran, 7, 1, 0, 0, 0, 0, time.UTC),
+				Akhir:       time.Date(tahunAjaran, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			scenarioName: "Error add semester ganjil twice in tahun ajaran",
+			request: schema.CreateSemesterRequest{
+				TahunAjaran: tahunAjaran,
+				Semester:    SemesterGanjil,
+				Mulai:       time.Date(tahunAjaran, 8, 1, 0, 0, 0, 0, time.UTC),
+				Akhir:       time.Date(tahunAjaran, 12, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expectedErrCode: apierror.SemesterDuplicate,
+		},
+		{
+			scenarioName: "Error add semester that is not ganjil or genap",
+			request: schema.CreateSemesterRequest{
+				TahunAjaran: tahunAjaran,
+				Semester:    "pendek",
+				Mulai:       time.Date(tahunAjaran+1, 7, 1, 0, 0, 0, 0, time.UTC),
+				Akhir:       time.Date(tahunAjaran+1, 7, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expectedErrCode: apierror.SemesterSemesterInvalid,
+		},
+		{
+			scenarioName: "Error add semester akhir before mulai",
+			request: schema.CreateSemesterRequest{
+				TahunAjaran: tahunAjaran,
+				Semester:    SemesterGenap,
+				Mulai:       time.Date(tahunAjaran+1, 6, 30, 0, 0, 0, 0, time.UTC),
+				Akhir:       time.Date(tahunAjaran+1, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedErrCode: apierror.SemesterAkhirBeforeMulai,
+		},
+		{
+			scenarioName: "Successful add semester genap",
+			request: schema.CreateSemesterRequest{
+				TahunAjaran: tahunAjaran,
+				Semester:    SemesterGenap,
+				Mulai:       time.Date(tahunAjaran+1, 1, 1, 0, 0, 0, 0, time.UTC),
+				Akhir:       time.Date(tahunAjaran+1, 6, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			_, err := s.CreateSemester(context.Background(), &v.request)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+		})
+	}
+}
+
+func TestActivateSemester(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewSemesterService(db)
+	tahunAjaran := newTahunAjaran()
+	ganjil, err := s.CreateSemester(context.Background(), &schema.CreateSemesterRequest{
+		TahunAjaran: tahunAjaran,
+		Semester:    SemesterGanjil,
+		Mulai:       time.Date(tahunAjaran, 7, 1, 0, 0, 0, 0, time.UTC),
+		Akhir:       time.Date(tahunAjaran, 12, 31, 0, 0, 0, 0, time.UTC),
+		Aktif:       true,
+	})
+	if err != nil {
+		t.Fatalf("create semester ganjil failed: %s", err)
+	}
+	genap, err := s.CreateSemester(context.Background(), &schema.CreateSemesterRequest{
+		TahunAjaran: tahunAjaran,
+		Semester:    SemesterGenap,
+		Mulai:       time.Date(tahunAjaran+1, 1, 1, 0, 0, 0, 0, time.UTC),
+		Akhir:       time.Date(tahunAjaran+1, 6, 30, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("create semester genap failed: %s", err)
+	}
+
+	testScenarios := []struct {
+		scenarioName   string
+		id             int
+		request        schema.UpdateSemesterRequest
+		expectedActive int
+	}{
+		{
+			scenarioName:   "Successful activate semester genap deactivates ganjil",
+			id:             genap.ID,
+			request:        schema.UpdateSemesterRequest{Aktif: optional.Of(true)},
+			expectedActive: genap.ID,
+		},
+		{
+			scenarioName:   "Successful update active semester keeps it active",
+			id:             genap.ID,
+			request:        schema.UpdateSemesterRequest{Akhir: optional.Of(time.Date(tahunAjaran+1, 6, 15, 0, 0, 0, 0, time.UTC))},
+			expectedActive: genap.ID,
+		},
+		{
+			scenarioName:   "Successful activate semester ganjil again",
+			id:             ganjil.ID,
+			request:        schema.UpdateSemesterRequest{Aktif: optional.Of(true)},
+			expectedActive: ganjil.ID,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			_, err := s.UpdateSemester(context.Background(), strconv.Itoa(v.id), &v.request)
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+
+			var active []int
+			err = db.Select(&active, "SELECT id FROM public.semester WHERE aktif AND deleted_at IS NULL")
+			if err != nil {
+				t.Errorf("expect no error, but got %s", err)
+				return
+			}
+			if len(active) != 1 || active[0] != v.expectedActive {
+				t.Errorf("expect only semester %d active, but got %v", v.expectedActive, active)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/syukur91/ischool-monitor/api/schema"
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/dbtx"
+)
+
+func TestCreateSiswa(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewSiswaService(db)
+	kelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Tingkat = 3 })
+	kelasWithoutWaliKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Tingkat = 3 })
+	waliKelas := newWaliKelas(t, db)
+	newKelasWaliKelas(t, db, kelas, waliKelas.ID)
+	deletedKelas := newKelas(t, db, func(request *schema.CreateKelasRequest) { request.Tingkat = 3 })
+	db.MustExec("UPDATE public.kelas SET deleted_at=now() WHERE id=$1", deletedKelas.ID)
+
+	testScenarios := []struct {
+		scenarioName        string
+		nama                string
+		idKelas             int
+		tingkat             int
+		alamat              string
+		expectedErrCode     apierror.Code
+		expectedIDWaliKelas *int
+	}{
+		{
+			scenarioName:        "Successful add siswa",
+			nama:                "Cendana",
+			idKelas:             kelas.ID,
+			alamat:              "Jalan Cendana",
+			tingkat:             3,
+			expectedIDWaliKelas: &waliKelas.ID,
+		},
+		{
+			scenarioName: "Successful add siswa to kelas without wali kelas",
+			nama:         "Cendano",
+			idKelas:      kelasWithoutWaliKelas.ID,
+			alamat:       "Jalan Cendana",
+			tingkat:      3,
+		},
+		{
+			scenarioName:    "Error add siswa kelas is deleted",
+			nama:            "Cendani",
+			idKelas:         deletedKelas.ID,
+			alamat:          "Jalan Cendana",
+			tingkat:         3,
+			expectedErrCode: apierror.SiswaKelasDeleted,
+		},
+		{
+			scenarioName:    "Error add siswa tingkat does not match kelas",
+			nama:            "Cendani",
+			idKelas:         kelas.ID,
+			alamat:          "Jalan Cendana",
+			tingkat:         5,
+			expectedErrCode: apierror.SiswaTingkatMismatch,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			siswa, err := s.CreateSiswa(context.Background(), &schema.CreateSiswaRequest{
+				Nama:    v.nama,
+				IDKelas: v.idKelas,
+				Alamat:  v.alamat,
+				Tingkat: v.tingkat,
+			})
+			//t.Logf("%+v, %+v", v, err)
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(siswa.IDWaliKelas, v.expectedIDWaliKelas) {
+				t.Errorf("expect id wali kelas %v, but got %v", v.expectedIDWaliKelas, siswa.IDWaliKelas)
+				return
+			}
+		})
+	}
+}
+
+func TestUpdateSiswa(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	s := NewSiswaService(db)
+	siswa := newSiswa(t, db)
+	other := newSiswa(t, db, func(request *schema.CreateSiswaRequest) { request.Nama = "Sari Dewi" })
+
+	testScenarios := []struct {
+		scenarioName        string
+		idKelas             int
+		tingkat             int
+		expectedErrCode     apierror.Code
+		expectedIDWaliKelas *int
+	}{
+		{
+			scenarioName:    "Failure update: tingkat does not match kelas",
+			tingkat:         2,
+			expectedErrCode: apierror.SiswaTingkatMismatch,
+		},
+		{
+			scenarioName:        "Successful move to kelas with other wali kelas",
+			idKelas:             other.IDKelas,
+			expectedIDWaliKelas: other.IDWaliKelas,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			updatedSiswaResponse, err := s.UpdateSiswa(context.Background(), strconv.Itoa(siswa.ID), &schema.UpdateSiswaRequest{
+				IDKelas: setNonZero(v.idKelas),
+				Tingkat: setNonZero(v.tingkat),
+			})
+
+			errCode := errorCode(err)
+			if v.expectedErrCode != errCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errCode)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(v.expectedIDWaliKelas, updatedSiswaResponse.IDWaliKelas) {
+				t.Errorf("expect id wali kelas %v, but got %v", v.expectedIDWaliKelas, updatedSiswaResponse.IDWaliKelas)
+				return
+			}
+		})
+	}
+}
+
+func TestCreateSiswa_UnitOfWork(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	kelasService := NewKelasService(db)
+	siswaService := NewSiswaService(db)
+
+	testScenarios := []struct {
+		scenarioName    string
+		fail            bool
+		expectedErrCode apierror.Code
+	}{
+		{
+			scenarioName: "Kelas and siswa are committed together",
+		},
+		{
+			scenarioName:    "Kelas is rolled back with siswa",
+			fail:            true,
+			expectedErrCode: apierror.KelasNotFound,
+		},
+	}
+
+	for _, v := range testScenarios {
+		t.Run(v.scenarioName, func(t *testing.T) {
+			var kelas *schema.KelasResponse
+			err := dbtx.WithTx(context.Background(), db, func(ctx context.Context, tx *sqlx.Tx) error {
+				var err error
+				kelas, err = kelasService.CreateKelas(ctx, &schema.CreateKelasRequest{Nama: "X IPS 1", Tingkat: 1, TahunAjaran: 2026})
+				if err != nil {
+					return err
+				}
+
+				_, err = siswaService.CreateSiswa(ctx, &schema.CreateSiswaRequest{Nama: "Cendana", IDKelas: kelas.ID, Tingkat: 1, Alamat: "Jalan Cendana"})
+				if err != nil {
+					return err
+				}
+
+				if v.fail {
+					return errors.New("link parent failed")
+				}
+				return nil
+			})
+			if (err != nil) != v.fail {
+				t.Errorf("expect failure %t, but got %v", v.fail, err)
+				return
+			}
+
+			_, err = kelasService.GetKelas(context.Background(), strconv.Itoa(kelas.ID))
+			if errorCode(err) != v.expectedErrCode {
+				t.Errorf("expect error code %s, but got %s", v.expectedErrCode, errorCode(err))
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/syukur91/ischool-monitor/pkg/apierror"
+	"github.com/syukur91/ischool-monitor/pkg/tracing"
+)
+
+// StatsService ...
+type StatsService struct {
+	db *sqlx.DB
+}
+
+// NewStatsService ...
+func NewStatsService(db *sqlx.DB) *StatsService {
+	return &StatsService{db: db}
+}
+
+// CountAttendanceToday counts attendance records written since start of today
+func (s *StatsService) CountAttendanceToday(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "StatsService.CountAttendanceToday")
+	defer func() { tracing.End(span, err) }()
+
+	total := 0
+	err = s.db.QueryRowContext(ctx, `
+		SELECT count(*)
+		FROM public.jam_pelajaran_siswa
+		WHERE created_at >= current_date AND deleted_at IS NULL;`).Scan(&total)
+	if err != nil {
+		return 0, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "countattendancetoday: get count failed"))
+	}
+
+	return total, nil
+}
+
+// CountAttendanceSemester counts attendance records written from mulai to akhir of active semester.
+// It returns false when no semester is active.
+func (s *StatsService) CountAttendanceSemester(ctx context.Context) (_ int, _ bool, err error) {
+	ctx, span := tracing.Start(ctx, "StatsService.CountAttendanceSemester")
+	defer func() { tracing.End(span, err) }()
+
+	total := 0
+	err = s.db.QueryRowContext(ctx, `
+		SELECT count(j.id)
+		FROM public.semester s
+		LEFT JOIN public.jam_pelajaran_siswa j
+			ON j.created_at >= s.mulai AND j.created_at < s.akhir + 1 AND j.deleted_at IS NULL
+		WHERE s.aktif AND s.deleted_at IS NULL
+		GROUP BY s.id;`).Scan(&total)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, apierror.NewError(http.StatusInternalServerError, apierror.DatabaseError, "Database transaction failed", errors.Wrap(err, "countattendancesemester: get count failed"))
+	}
+
+	return total, true, nil
+}
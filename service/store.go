@@ -0,0 +1,81 @@
+package service
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Store returns repository of every generated model. DBStore returns services on database and
+// memory.Store returns repositories in memory, so main and api tests build handlers the same way,
+// see api.NewServices. Add method of model here when it is added to template/models.yaml.
+type Store interface {
+	Mata_Pelajaran() Mata_PelajaranRepository
+	Kelas() KelasRepository
+	Wali_Kelas() Wali_KelasRepository
+	Kelas_Wali_Kelas() Kelas_Wali_KelasRepository
+	Siswa() SiswaRepository
+	User() UserRepository
+	Semester() SemesterRepository
+}
+
+var _ Store = (*DBStore)(nil)
+
+// DBStore returns services on database. Services that are only written for database, such as
+// kenaikan kelas, are returned by it too.
+type DBStore struct {
+	db *sqlx.DB
+}
+
+// NewDBStore ...
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Mata_Pelajaran ...
+func (s *DBStore) Mata_Pelajaran() Mata_PelajaranRepository {
+	return NewMata_PelajaranService(s.db)
+}
+
+// Kelas ...
+func (s *DBStore) Kelas() KelasRepository {
+	return NewKelasService(s.db)
+}
+
+// Wali_Kelas ...
+func (s *DBStore) Wali_Kelas() Wali_KelasRepository {
+	return NewWali_KelasService(s.db)
+}
+
+// Kelas_Wali_Kelas ...
+func (s *DBStore) Kelas_Wali_Kelas() Kelas_Wali_KelasRepository {
+	return NewKelas_Wali_KelasService(s.db)
+}
+
+// Siswa ...
+func (s *DBStore) Siswa() SiswaRepository {
+	return NewSiswaService(s.db)
+}
+
+// User ...
+func (s *DBStore) User() UserRepository {
+	return NewUserService(s.db)
+}
+
+// Semester ...
+func (s *DBStore) Semester() SemesterRepository {
+	return NewSemesterService(s.db)
+}
+
+// KenaikanKelas ...
+func (s *DBStore) KenaikanKelas() KenaikanKelasRepository {
+	return NewKenaikanKelasService(s.db)
+}
+
+// RiwayatKelas ...
+func (s *DBStore) RiwayatKelas() RiwayatKelasRepository {
+	return NewRiwayatKelasService(s.db)
+}
+
+// Kehadiran ...
+func (s *DBStore) Kehadiran() KehadiranRepository {
+	return NewKehadiranService(s.db)
+}
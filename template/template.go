@@ -1,6 +1,6 @@
 package template
 
-//go:generate go run cmd/gen.go
+//go:generate go run ../cmd/gen
 
 func noop() {
 